@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"net/http"
@@ -11,7 +12,9 @@ import (
 	"room-service/constants"
 	controllers "room-service/controllers"
 	"room-service/domain/models"
+	"room-service/jobs"
 	"room-service/middlewares"
+	"room-service/migrations"
 	"room-service/repositories"
 	"room-service/routes"
 	"room-service/services"
@@ -41,10 +44,26 @@ var commad = &cobra.Command{
 		}
 		time.Local = loc
 
+		err = migrations.Run(db)
+		if err != nil {
+			panic(err)
+		}
+
 		err = db.AutoMigrate(
 			&models.Room{},
 			&models.RoomSchedule{},
 			&models.Time{},
+			&models.Booking{},
+			&models.BookingSchedule{},
+			&models.Closure{},
+			&models.RoomSlotTemplate{},
+			&models.BookingQuota{},
+			&models.Amenity{},
+			&models.CalendarFeed{},
+			&models.WaitlistEntry{},
+			&models.Strike{},
+			&models.Suspension{},
+			&models.BookingSeries{},
 		)
 		if err != nil {
 			panic(err)
@@ -55,6 +74,8 @@ var commad = &cobra.Command{
 		repository := repositories.NewRepositoryRegistry(db)
 		service := services.NewServiceRegistry(repository, gcs)
 		controller := controllers.NewControllerRegistry(service)
+		job := jobs.NewJobRegistry(repository, service)
+		job.Start(context.Background())
 
 		router := gin.Default()
 		router.Use(middlewares.HandlePanic())
@@ -0,0 +1,110 @@
+// Package ical writes iCalendar (RFC 5545) documents.
+package ical
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusTentative = "TENTATIVE"
+
+	dateTimeLayout = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Status      string
+	Start       time.Time
+	End         time.Time
+	UpdatedAt   time.Time
+}
+
+type Calendar struct {
+	ProductID string
+	Name      string
+	Events    []Event
+}
+
+// Bytes renders the calendar with CRLF line endings and long lines folded.
+// Times are written in UTC.
+func (c *Calendar) Bytes() []byte {
+	var builder strings.Builder
+	write := func(name, value string) {
+		builder.WriteString(fold(name + ":" + value))
+		builder.WriteString("\r\n")
+	}
+
+	write("BEGIN", "VCALENDAR")
+	write("VERSION", "2.0")
+	write("PRODID", escape(c.ProductID))
+	write("CALSCALE", "GREGORIAN")
+	write("METHOD", "PUBLISH")
+	if c.Name != "" {
+		write("X-WR-CALNAME", escape(c.Name))
+	}
+
+	for _, event := range c.Events {
+		write("BEGIN", "VEVENT")
+		write("UID", escape(event.UID))
+		write("DTSTAMP", event.UpdatedAt.UTC().Format(dateTimeLayout))
+		write("DTSTART", event.Start.UTC().Format(dateTimeLayout))
+		write("DTEND", event.End.UTC().Format(dateTimeLayout))
+		write("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			write("DESCRIPTION", escape(event.Description))
+		}
+		if event.Location != "" {
+			write("LOCATION", escape(event.Location))
+		}
+		if event.Status != "" {
+			write("STATUS", event.Status)
+		}
+		write("END", "VEVENT")
+	}
+
+	write("END", "VCALENDAR")
+	return []byte(builder.String())
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+	`,`, `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+func escape(value string) string {
+	return textEscaper.Replace(value)
+}
+
+// fold splits a content line into lines of at most 75 octets, continuing
+// each with a leading space and never splitting a UTF-8 sequence.
+func fold(line string) string {
+	if len(line) <= maxLineOctets {
+		return line
+	}
+
+	var builder strings.Builder
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		builder.WriteString(line[:cut])
+		builder.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+	builder.WriteString(line)
+	return builder.String()
+}
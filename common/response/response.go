@@ -1,11 +1,13 @@
 package response
 
 import (
+	"errors"
 	"net/http"
 	"room-service/constants"
 	errConstant "room-service/constants/error"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
 type Response struct {
@@ -16,7 +18,7 @@ type Response struct {
 }
 
 type ParamHTTPResp struct {
-	Code    int
+	Code    int // hanya untuk response sukses, status error ditentukan dari Err
 	Err     error
 	Message *string
 	Gin     *gin.Context
@@ -37,18 +39,26 @@ func HTTPResponse(param ParamHTTPResp) {
 		return
 	}
 
-	// Menentukan pesan error
+	// Menentukan status dan pesan error dari jenis error-nya, error yang tidak
+	// dikenal dianggap internal server error
+	code := http.StatusInternalServerError
 	message := errConstant.ErrInternalServerError.Error()
+	var statusErr errConstant.StatusError
+	var validationErr validator.ValidationErrors
+	if errors.As(param.Err, &statusErr) {
+		code = statusErr.HTTPStatus()
+		message = statusErr.Error()
+	} else if errors.As(param.Err, &validationErr) {
+		code = http.StatusUnprocessableEntity
+		message = http.StatusText(http.StatusUnprocessableEntity)
+	}
+
 	if param.Message != nil {
 		message = *param.Message
-	} else if param.Err != nil {
-		if errConstant.ErrMapping(param.Err) {
-			message = param.Err.Error()
-		}
 	}
 
 	// Kirim response error
-	param.Gin.JSON(param.Code, Response{
+	param.Gin.JSON(code, Response{
 		Status:  constants.Error,
 		Message: message,
 		Data:    nil, // Data dikosongkan jika terjadi error untuk menjaga konsistensi response
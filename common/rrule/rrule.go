@@ -0,0 +1,171 @@
+// Package rrule expands the subset of iCalendar (RFC 5545) recurrence rules
+// used by recurring bookings: FREQ=DAILY or WEEKLY with INTERVAL, BYDAY,
+// COUNT and UNTIL. Weeks start on Monday.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FreqDaily  = "DAILY"
+	FreqWeekly = "WEEKLY"
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+type Rule struct {
+	Freq     string
+	Interval int
+	ByDay    []time.Weekday
+	Count    int
+	Until    *time.Time
+}
+
+// Parse reads a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH". An
+// "RRULE:" prefix is accepted.
+func Parse(value string) (*Rule, error) {
+	rule := &Rule{Interval: 1}
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("rrule: malformed part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(val)
+			if rule.Freq != FreqDaily && rule.Freq != FreqWeekly {
+				return nil, fmt.Errorf("rrule: unsupported FREQ %q", val)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("rrule: invalid INTERVAL %q", val)
+			}
+			rule.Interval = interval
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				weekday, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					return nil, fmt.Errorf("rrule: invalid BYDAY %q", day)
+				}
+				if !slices.Contains(rule.ByDay, weekday) {
+					rule.ByDay = append(rule.ByDay, weekday)
+				}
+			}
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("rrule: invalid COUNT %q", val)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseUntil(val)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+		default:
+			return nil, fmt.Errorf("rrule: unsupported part %q", key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, errors.New("rrule: FREQ is required")
+	}
+
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if len(value) >= 8 {
+		until, err := time.Parse("20060102", value[:8])
+		if err == nil {
+			return until, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("rrule: invalid UNTIL %q", value)
+}
+
+// weekOffset returns how many days after Monday the weekday falls.
+func weekOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+// Between returns the dates the rule produces from start through end, both
+// inclusive and taken as dates in start's location. start is the first
+// candidate date and, when BYDAY is not given, sets the weekday of a weekly
+// rule. No more than limit dates are returned when limit is positive.
+func (r *Rule) Between(start, end time.Time, limit int) []time.Time {
+	toDate := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, start.Location())
+	}
+
+	start, end = toDate(start), toDate(end)
+	if r.Until != nil && toDate(*r.Until).Before(end) {
+		end = toDate(*r.Until)
+	}
+
+	maxDates := r.Count
+	if limit > 0 && (maxDates == 0 || limit < maxDates) {
+		maxDates = limit
+	}
+
+	var dates []time.Time
+	full := func() bool { return maxDates > 0 && len(dates) >= maxDates }
+
+	if r.Freq == FreqDaily {
+		for date := start; !date.After(end) && !full(); date = date.AddDate(0, 0, r.Interval) {
+			dates = append(dates, date)
+		}
+		return dates
+	}
+
+	byDay := r.ByDay
+	if len(byDay) == 0 {
+		byDay = []time.Weekday{start.Weekday()}
+	}
+
+	offsets := make([]int, 0, len(byDay))
+	for _, weekday := range byDay {
+		offsets = append(offsets, weekOffset(weekday))
+	}
+	sort.Ints(offsets)
+
+	weekStart := start.AddDate(0, 0, -weekOffset(start.Weekday()))
+	for ; !weekStart.After(end) && !full(); weekStart = weekStart.AddDate(0, 0, 7*r.Interval) {
+		for _, offset := range offsets {
+			date := weekStart.AddDate(0, 0, offset)
+			if date.Before(start) {
+				continue
+			}
+			if date.After(end) || full() {
+				break
+			}
+			dates = append(dates, date)
+		}
+	}
+
+	return dates
+}
@@ -0,0 +1,163 @@
+package rrule
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func date(value string) time.Time {
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParse(t *testing.T) {
+	until := date("2026-01-31")
+	tests := []struct {
+		name  string
+		value string
+		want  *Rule
+	}{
+		{
+			name:  "weekly with prefix, interval and days",
+			value: "RRULE:FREQ=weekly;INTERVAL=2;BYDAY=tu,th",
+			want:  &Rule{Freq: FreqWeekly, Interval: 2, ByDay: []time.Weekday{time.Tuesday, time.Thursday}},
+		},
+		{
+			name:  "daily with count",
+			value: "FREQ=DAILY;COUNT=5",
+			want:  &Rule{Freq: FreqDaily, Interval: 1, Count: 5},
+		},
+		{
+			name:  "until with time part",
+			value: "FREQ=DAILY;UNTIL=20260131T235959Z",
+			want:  &Rule{Freq: FreqDaily, Interval: 1, Until: &until},
+		},
+		{
+			name:  "duplicate days",
+			value: "FREQ=WEEKLY;BYDAY=TU,MO,TU",
+			want:  &Rule{Freq: FreqWeekly, Interval: 1, ByDay: []time.Weekday{time.Tuesday, time.Monday}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Parse(test.value)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", test.value, err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("Parse(%q) = %+v, want %+v", test.value, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	values := []string{
+		"",
+		"FREQ",
+		"INTERVAL=2",
+		"FREQ=MONTHLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=DAILY;UNTIL=2026",
+		"FREQ=DAILY;BYMONTH=1",
+	}
+
+	for _, value := range values {
+		_, err := Parse(value)
+		if err == nil {
+			t.Errorf("Parse(%q) returned no error", value)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       string
+		start, end string
+		limit      int
+		want       []string
+	}{
+		{
+			name:  "daily with interval",
+			rule:  "FREQ=DAILY;INTERVAL=2",
+			start: "2026-01-05", end: "2026-01-12",
+			want: []string{"2026-01-05", "2026-01-07", "2026-01-09", "2026-01-11"},
+		},
+		{
+			name:  "weekly on the start weekday",
+			rule:  "FREQ=WEEKLY",
+			start: "2026-01-07", end: "2026-01-28",
+			want: []string{"2026-01-07", "2026-01-14", "2026-01-21", "2026-01-28"},
+		},
+		{
+			name:  "week starting mid-week skips earlier days",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+			start: "2026-01-07", end: "2026-01-16",
+			want: []string{"2026-01-07", "2026-01-09", "2026-01-12", "2026-01-14", "2026-01-16"},
+		},
+		{
+			name:  "weekly interval counts weeks from the start week",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			start: "2026-01-07", end: "2026-02-05",
+			want: []string{"2026-01-08", "2026-01-19", "2026-01-22", "2026-02-02", "2026-02-05"},
+		},
+		{
+			name:  "count reached before until",
+			rule:  "FREQ=DAILY;COUNT=3;UNTIL=20260131",
+			start: "2026-01-05", end: "2026-01-31",
+			want: []string{"2026-01-05", "2026-01-06", "2026-01-07"},
+		},
+		{
+			name:  "until reached before count",
+			rule:  "FREQ=DAILY;COUNT=10;UNTIL=20260107",
+			start: "2026-01-05", end: "2026-01-31",
+			want: []string{"2026-01-05", "2026-01-06", "2026-01-07"},
+		},
+		{
+			name:  "count starts at the first date after a mid-week start",
+			rule:  "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=3",
+			start: "2026-01-07", end: "2026-01-31",
+			want: []string{"2026-01-08", "2026-01-13", "2026-01-15"},
+		},
+		{
+			name:  "limit below count",
+			rule:  "FREQ=DAILY;COUNT=5",
+			start: "2026-01-05", end: "2026-01-31",
+			limit: 2,
+			want:  []string{"2026-01-05", "2026-01-06"},
+		},
+		{
+			name:  "duplicate days produce each date once",
+			rule:  "FREQ=WEEKLY;BYDAY=TU,TU",
+			start: "2026-01-05", end: "2026-01-13",
+			want: []string{"2026-01-06", "2026-01-13"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule, err := Parse(test.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", test.rule, err)
+			}
+
+			got := make([]string, 0)
+			for _, day := range rule.Between(date(test.start), date(test.end), test.limit) {
+				got = append(got, day.Format(time.DateOnly))
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("Between = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
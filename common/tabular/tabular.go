@@ -0,0 +1,104 @@
+// Package tabular reads and writes plain tables as CSV or XLSX files. Only
+// the first sheet of a workbook is read.
+package tabular
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var ErrUnsupportedFormat = errors.New("tabular: unsupported format")
+
+var contentTypes = map[string]string{
+	FormatCSV:  "text/csv",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// FormatOf returns the format of a file from its extension.
+func FormatOf(fileName string) string {
+	return strings.ToLower(strings.TrimPrefix(filepath.Ext(fileName), "."))
+}
+
+// ContentType returns the MIME type of the format.
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+// Read returns the rows of the table in the given format.
+func Read(format string, r io.Reader) ([][]string, error) {
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		return reader.ReadAll()
+	case FormatXLSX:
+		file, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		sheets := file.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, nil
+		}
+		return file.GetRows(sheets[0])
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// Write encodes the rows as a table in the given format.
+func Write(format string, rows [][]string) ([]byte, error) {
+	switch format {
+	case FormatCSV:
+		var buffer bytes.Buffer
+		writer := csv.NewWriter(&buffer)
+		err := writer.WriteAll(rows)
+		if err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), nil
+	case FormatXLSX:
+		file := excelize.NewFile()
+		defer file.Close()
+
+		sheet := file.GetSheetName(0)
+		for i, row := range rows {
+			cell, err := excelize.CoordinatesToCellName(1, i+1)
+			if err != nil {
+				return nil, err
+			}
+
+			values := make([]any, 0, len(row))
+			for _, value := range row {
+				values = append(values, value)
+			}
+
+			err = file.SetSheetRow(sheet, cell, &values)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		buffer, err := file.WriteToBuffer()
+		if err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
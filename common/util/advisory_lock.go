@@ -0,0 +1,35 @@
+package util
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// WithAdvisoryLock runs fn while holding the Postgres session advisory lock
+// identified by key. It returns false without running fn when another
+// session, usually another replica, already holds the lock.
+func WithAdvisoryLock(ctx context.Context, db *gorm.DB, key int64, fn func(context.Context) error) (bool, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return false, err
+	}
+
+	// Session locks belong to a connection, so lock and unlock on the same one.
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	var locked bool
+	err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked)
+	if err != nil || !locked {
+		return false, err
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key)
+	}()
+
+	return true, fn(ctx)
+}
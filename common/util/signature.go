@@ -0,0 +1,33 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// GenerateToken returns size random bytes, hex encoded.
+func GenerateToken(size int) (string, error) {
+	token := make([]byte, size)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(token), nil
+}
+
+// Sign returns the hex HMAC-SHA256 of the values joined with ":".
+func Sign(key string, values ...string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(strings.Join(values, ":")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature compares signature with the one Sign returns in constant
+// time.
+func VerifySignature(key, signature string, values ...string) bool {
+	return hmac.Equal([]byte(signature), []byte(Sign(key, values...)))
+}
@@ -0,0 +1,64 @@
+package util
+
+import (
+	"fmt"
+	"time"
+)
+
+var clockLayouts = []string{
+	"15:04:05Z07",
+	"15:04:05",
+	"15:04Z07",
+	"15:04",
+}
+
+// ParseClock parses a time of day as returned by the times table.
+func ParseClock(value string) (time.Time, error) {
+	for _, layout := range clockLayouts {
+		clock, err := time.Parse(layout, value)
+		if err == nil {
+			return clock, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time of day: %s", value)
+}
+
+// FormatClock normalizes a time of day to HH:MM, returning the value as is
+// when it cannot be parsed.
+func FormatClock(value string) string {
+	clock, err := ParseClock(value)
+	if err != nil {
+		return value
+	}
+
+	return clock.Format("15:04")
+}
+
+// CombineDateAndClock returns the instant a slot on the given date starts or
+// ends, in the server's local time zone.
+func CombineDateAndClock(date time.Time, clock string) (time.Time, error) {
+	parsed, err := ParseClock(clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(
+		date.Year(),
+		date.Month(),
+		date.Day(),
+		parsed.Hour(),
+		parsed.Minute(),
+		parsed.Second(),
+		0,
+		time.Local,
+	), nil
+}
+
+// TruncateToDate drops the clock part of t, keeping its calendar date. Dates
+// are kept in UTC like the ones parsed from requests and read from the
+// database.
+func TruncateToDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -58,6 +59,22 @@ func GeneratePagination(params PaginationParam) PaginationResult {
 	return result
 }
 
+// SplitList splits a comma separated query value into lower-cased, trimmed
+// and de-duplicated items.
+func SplitList(value string) []string {
+	items := make([]string, 0)
+	isAdded := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item != "" && !isAdded[item] {
+			items = append(items, item)
+			isAdded[item] = true
+		}
+	}
+
+	return items
+}
+
 func GenerateSHA256(inputString string) string {
 	hash := sha256.New()
 	hash.Write([]byte(inputString))
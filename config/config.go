@@ -10,20 +10,92 @@ import (
 var Config AppConfig
 
 type AppConfig struct {
-	Port                  int             `json:"port"`
-	AppName               string          `json:"appName"`
-	AppEnv                string          `json:"appEnv"`
-	SignatureKey          string          `json:"signatureKey"`
-	Database              Database        `json:"database"`
-	RateLimiterMaxRequest float64         `json:"rateLimiterMaxRequest"` // Dikembalikan ke float64
-	RateLimiterTimeSecond int             `json:"rateLimiterTimeSecond"`
-	InternalService       InternalService `json:"internalService"`
-	GcsType               string          `json:"gcsType"`
-	GcsProjectID          string          `json:"gcsProjectID"`
-	GcsPrivateKeyID       string          `json:"gcsPrivateKeyID"`
-	GcsPrivateKey         string          `json:"gcsPrivateKey"`
-	GcsClientEmail        string          `json:"gcsClientEmail"`
-	GcsClientID           string          `json:"gcsClientID"`
+	Port                  int               `json:"port"`
+	AppName               string            `json:"appName"`
+	AppEnv                string            `json:"appEnv"`
+	SignatureKey          string            `json:"signatureKey"`
+	Database              Database          `json:"database"`
+	RateLimiterMaxRequest float64           `json:"rateLimiterMaxRequest"` // Dikembalikan ke float64
+	RateLimiterTimeSecond int               `json:"rateLimiterTimeSecond"`
+	InternalService       InternalService   `json:"internalService"`
+	GcsType               string            `json:"gcsType"`
+	GcsProjectID          string            `json:"gcsProjectID"`
+	GcsPrivateKeyID       string            `json:"gcsPrivateKeyID"`
+	GcsPrivateKey         string            `json:"gcsPrivateKey"`
+	GcsClientEmail        string            `json:"gcsClientEmail"`
+	GcsClientID           string            `json:"gcsClientID"`
+	Booking               Booking           `json:"booking"`
+	ScheduleGenerator     ScheduleGenerator `json:"scheduleGenerator"`
+	CalendarFeed          CalendarFeed      `json:"calendarFeed"`
+	Waitlist              Waitlist          `json:"waitlist"`
+	Hold                  Hold              `json:"hold"`
+	CheckIn               CheckIn           `json:"checkIn"`
+	Penalty               Penalty           `json:"penalty"`
+	BookingSeries         BookingSeries     `json:"bookingSeries"`
+	Migration             Migration         `json:"migration"`
+}
+
+type Booking struct {
+	CancellationCutoffMinute int `json:"cancellationCutoffMinute"`
+	MaxDurationMinute        int `json:"maxDurationMinute"`
+}
+
+type ScheduleGenerator struct {
+	Enabled        bool `json:"enabled"`
+	IntervalMinute int  `json:"intervalMinute"`
+	DaysAhead      int  `json:"daysAhead"`
+}
+
+// CalendarFeed sets how many days before and after today an ICS feed covers.
+type CalendarFeed struct {
+	PastDays   int `json:"pastDays"`
+	FutureDays int `json:"futureDays"`
+}
+
+// Waitlist sets how long a freed slot is held for the next user in line, and
+// how often expired offers are passed on.
+type Waitlist struct {
+	OfferMinute    int `json:"offerMinute"`
+	IntervalMinute int `json:"intervalMinute"`
+}
+
+// Hold sets how long slots picked in the booking UI stay reserved, how many a
+// user may hold at once, and how often expired holds are released.
+type Hold struct {
+	DurationMinute int `json:"durationMinute"`
+	MaxSlots       int `json:"maxSlots"`
+	IntervalSecond int `json:"intervalSecond"`
+}
+
+// CheckIn sets the window around a booking's start in which it can be
+// checked in, how often missed check-ins are released as no-shows, and how
+// many days back no-shows count against a quota.
+type CheckIn struct {
+	EarlyMinute      int `json:"earlyMinute"`
+	GraceMinute      int `json:"graceMinute"`
+	IntervalMinute   int `json:"intervalMinute"`
+	NoShowWindowDays int `json:"noShowWindowDays"`
+}
+
+// Penalty sets how many strikes within how many days suspend a user from
+// booking and for how long, and how close to its start cancelling a booking
+// counts as a strike. Zero turns the respective rule off.
+type Penalty struct {
+	StrikeThreshold        int `json:"strikeThreshold"`
+	StrikeWindowDays       int `json:"strikeWindowDays"`
+	SuspensionDays         int `json:"suspensionDays"`
+	LateCancellationMinute int `json:"lateCancellationMinute"`
+}
+
+// BookingSeries caps how many occurrences one recurring booking may have.
+type BookingSeries struct {
+	MaxOccurrences int `json:"maxOccurrences"`
+}
+
+// Migration sets the library given to rooms and time slots created before
+// they were scoped to a library.
+type Migration struct {
+	DefaultLibrary string `json:"defaultLibrary"`
 }
 
 type InternalService struct {
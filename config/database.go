@@ -26,7 +26,9 @@ func InitDatabase() (*gorm.DB, error) {
 	)
 
 	// Membuka koneksi ke database dengan Gorm
-	db, err := gorm.Open(postgres.Open(uri), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(uri), &gorm.Config{
+		TranslateError: true,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1,5 +1,7 @@
 package constants
 
 const (
-	Token = "token"
+	Token   = "token"
+	User    = "user"
+	Library = "library"
 )
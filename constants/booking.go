@@ -0,0 +1,52 @@
+package constants
+
+type BookingStatusName string
+type BookingStatus int
+
+const (
+	BookingConfirmed BookingStatus = 100
+	BookingCancelled BookingStatus = 200
+	BookingPending   BookingStatus = 300
+	BookingRejected  BookingStatus = 400
+	BookingCheckedIn BookingStatus = 500
+	BookingNoShow    BookingStatus = 600
+
+	BookingConfirmedString BookingStatusName = "Confirmed"
+	BookingCancelledString BookingStatusName = "Cancelled"
+	BookingPendingString   BookingStatusName = "Pending"
+	BookingRejectedString  BookingStatusName = "Rejected"
+	BookingCheckedInString BookingStatusName = "CheckedIn"
+	BookingNoShowString    BookingStatusName = "NoShow"
+)
+
+var mapBookingStatusIntToString = map[BookingStatus]BookingStatusName{
+	BookingConfirmed: BookingConfirmedString,
+	BookingCancelled: BookingCancelledString,
+	BookingPending:   BookingPendingString,
+	BookingRejected:  BookingRejectedString,
+	BookingCheckedIn: BookingCheckedInString,
+	BookingNoShow:    BookingNoShowString,
+}
+
+var mapBookingStatusStringToInt = map[BookingStatusName]BookingStatus{
+	BookingConfirmedString: BookingConfirmed,
+	BookingCancelledString: BookingCancelled,
+	BookingPendingString:   BookingPending,
+	BookingRejectedString:  BookingRejected,
+	BookingCheckedInString: BookingCheckedIn,
+	BookingNoShowString:    BookingNoShow,
+}
+
+func (b BookingStatus) GetStatusString() BookingStatusName {
+	return mapBookingStatusIntToString[b]
+}
+
+func (b BookingStatusName) GetStatusInt() BookingStatus {
+	return mapBookingStatusStringToInt[b]
+}
+
+// Scopes of a booking series cancellation.
+const (
+	SeriesCancelOccurrence = "occurrence"
+	SeriesCancelRemainder  = "remainder"
+)
@@ -0,0 +1,6 @@
+package constants
+
+const (
+	CalendarFeedScopeRoom = "room"
+	CalendarFeedScopeUser = "user"
+)
@@ -0,0 +1,8 @@
+package error
+
+import errConstant "room-service/constants/error"
+
+var (
+	ErrAmenityNotFound = errConstant.NewNotFound("amenity not found")
+	ErrAmenityIsExist  = errConstant.NewConflict("amenity already exist")
+)
@@ -0,0 +1,57 @@
+package error
+
+import (
+	"fmt"
+	"net/http"
+	errConstant "room-service/constants/error"
+	"strings"
+)
+
+var (
+	ErrBookingNotFound           = errConstant.NewNotFound("booking not found")
+	ErrBookingAlreadyCancelled   = errConstant.NewConflict("booking already cancelled")
+	ErrBookingCancellationCutoff = errConstant.NewConflict("booking can no longer be cancelled")
+	ErrBookingNotPending         = errConstant.NewConflict("booking is not waiting for approval")
+	ErrBookingNotCancellable     = errConstant.NewConflict("booking cannot be cancelled")
+	ErrBookingNotContiguous      = errConstant.NewUnprocessable("requested time is not covered by consecutive slots")
+	ErrBookingExceedsMaxDuration = errConstant.NewUnprocessable("booking exceeds the maximum duration")
+	ErrBookingExceedsCapacity    = errConstant.NewUnprocessable("attendees exceed the room capacity")
+	ErrBookingNotCheckInable     = errConstant.NewConflict("booking cannot be checked in")
+	ErrBookingAlreadyCheckedIn   = errConstant.NewConflict("booking already checked in")
+	ErrBookingCheckInWindow      = errConstant.NewConflict("booking is outside its check-in window")
+	ErrBookingNoneToCheckIn      = errConstant.NewNotFound("no booking is due for check-in in this room")
+	ErrBookingInvalidCheckIn     = errConstant.NewForbidden("invalid check-in token")
+
+	ErrBookingSeriesNotFound         = errConstant.NewNotFound("booking series not found")
+	ErrBookingSeriesInvalidRule      = errConstant.NewUnprocessable("invalid recurrence rule")
+	ErrBookingSeriesEmpty            = errConstant.NewUnprocessable("recurrence rule produces no dates in the range")
+	ErrBookingSeriesTooLong          = errConstant.NewUnprocessable("booking series has too many occurrences")
+	ErrBookingSeriesAlreadyCancelled = errConstant.NewConflict("booking series already cancelled")
+	ErrBookingSeriesNoOccurrence     = errConstant.NewNotFound("booking series has no occurrence on this date")
+)
+
+// SeriesConflict is an occurrence of a booking series that could not be
+// booked, with the reason why.
+type SeriesConflict struct {
+	Date   string `json:"date"`
+	Reason string `json:"reason"`
+}
+
+// SeriesConflictError is returned when occurrences of a booking series could
+// not be booked, so none of them were.
+type SeriesConflictError struct {
+	Conflicts []SeriesConflict
+}
+
+func (e *SeriesConflictError) Error() string {
+	conflicts := make([]string, 0, len(e.Conflicts))
+	for _, conflict := range e.Conflicts {
+		conflicts = append(conflicts, fmt.Sprintf("%s: %s", conflict.Date, conflict.Reason))
+	}
+
+	return "booking series conflicts on " + strings.Join(conflicts, "; ")
+}
+
+func (e *SeriesConflictError) HTTPStatus() int {
+	return http.StatusConflict
+}
@@ -0,0 +1,12 @@
+package error
+
+import errConstant "room-service/constants/error"
+
+var (
+	ErrBookingQuotaNotFound   = errConstant.NewNotFound("booking quota not found")
+	ErrBookingQuotaIsExist    = errConstant.NewConflict("booking quota already exist")
+	ErrQuotaMaxHoursPerDay    = errConstant.NewUnprocessable("daily booking hours quota exceeded")
+	ErrQuotaMaxActiveBookings = errConstant.NewUnprocessable("active bookings quota exceeded")
+	ErrQuotaMaxDaysInAdvance  = errConstant.NewUnprocessable("booking is too far in advance")
+	ErrQuotaMaxNoShows        = errConstant.NewForbidden("too many recent no-shows")
+)
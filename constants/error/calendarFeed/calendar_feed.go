@@ -0,0 +1,9 @@
+package error
+
+import errConstant "room-service/constants/error"
+
+var (
+	ErrCalendarFeedNotFound     = errConstant.NewNotFound("calendar feed not found")
+	ErrCalendarFeedInvalidToken = errConstant.NewUnauthorized("invalid calendar feed token")
+	ErrCalendarFeedForbidden    = errConstant.NewForbidden("you are not allowed to revoke this calendar feed")
+)
@@ -0,0 +1,8 @@
+package error
+
+import errConstant "room-service/constants/error"
+
+var (
+	ErrClosureNotFound = errConstant.NewNotFound("closure not found")
+	ErrRoomClosed      = errConstant.NewConflict("room is closed on this date")
+)
@@ -1,6 +1,6 @@
 package error
 
-import "errors"
+import "net/http"
 
 const (
 	Success = "Success"
@@ -8,21 +8,13 @@ const (
 )
 
 var (
-	ErrInternalServerError = errors.New("internal server error")
-	ErrSQLError            = errors.New("database server failed to execute query")
-	ErrTooMannyRequests    = errors.New("too many request")
-	ErrUnauthorized        = errors.New("unauthorized")
-	ErrInvalidToken        = errors.New("invalid token")
-	ErrInvalidUploadFile   = errors.New("invalid upload file")
-	ErrSizeTooBig          = errors.New("size too big")
-	ErrForbidden           = errors.New("for bidden bro")
+	ErrInternalServerError = NewInternal("internal server error")
+	ErrSQLError            = NewInternal("database server failed to execute query")
+	ErrTooMannyRequests    = New(http.StatusTooManyRequests, "too many request")
+	ErrUnauthorized        = NewUnauthorized("unauthorized")
+	ErrInvalidToken        = NewUnauthorized("invalid token")
+	ErrInvalidUploadFile   = NewUnprocessable("invalid upload file")
+	ErrSizeTooBig          = New(http.StatusRequestEntityTooLarge, "size too big")
+	ErrForbidden           = NewForbidden("for bidden bro")
+	ErrInvalidRequest      = NewBadRequest("invalid request")
 )
-
-var GeneralErrors = []error{
-	ErrInternalServerError,
-	ErrSQLError,
-	ErrTooMannyRequests,
-	ErrUnauthorized,
-	ErrInvalidToken,
-	ErrForbidden,
-}
@@ -0,0 +1,64 @@
+package error
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// StatusError is an error that knows the HTTP status it is reported with.
+type StatusError interface {
+	error
+	HTTPStatus() int
+}
+
+// HTTPError is a domain error with a fixed HTTP status. Its message is safe
+// to show to clients.
+type HTTPError struct {
+	Status  int
+	Message string
+}
+
+func (e *HTTPError) Error() string {
+	return e.Message
+}
+
+func (e *HTTPError) HTTPStatus() int {
+	return e.Status
+}
+
+func New(status int, message string) error {
+	return &HTTPError{Status: status, Message: message}
+}
+
+func NewBadRequest(message string) error {
+	return New(http.StatusBadRequest, message)
+}
+
+func NewUnauthorized(message string) error {
+	return New(http.StatusUnauthorized, message)
+}
+
+func NewForbidden(message string) error {
+	return New(http.StatusForbidden, message)
+}
+
+func NewNotFound(message string) error {
+	return New(http.StatusNotFound, message)
+}
+
+func NewConflict(message string) error {
+	return New(http.StatusConflict, message)
+}
+
+func NewUnprocessable(message string) error {
+	return New(http.StatusUnprocessableEntity, message)
+}
+
+func NewInternal(message string) error {
+	return New(http.StatusInternalServerError, message)
+}
+
+// InvalidRequest reports err, from binding a request, as ErrInvalidRequest.
+func InvalidRequest(err error) error {
+	return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+}
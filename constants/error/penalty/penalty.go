@@ -0,0 +1,11 @@
+package error
+
+import errConstant "room-service/constants/error"
+
+var (
+	ErrStrikeNotFound          = errConstant.NewNotFound("strike not found")
+	ErrStrikeAlreadyCleared    = errConstant.NewConflict("strike already cleared")
+	ErrSuspensionNotFound      = errConstant.NewNotFound("suspension not found")
+	ErrSuspensionAlreadyLifted = errConstant.NewConflict("suspension already lifted")
+	ErrUserSuspended           = errConstant.NewForbidden("your booking privileges are suspended")
+)
@@ -1,11 +1,13 @@
 package error
 
-import "errors"
+import errConstant "room-service/constants/error"
 
 var (
-	ErrRoomNotFound = errors.New("room not found")
+	ErrRoomNotFound           = errConstant.NewNotFound("room not found")
+	ErrRoomCodeExists         = errConstant.NewConflict("room code already exist")
+	ErrRoomFileFormat         = errConstant.NewUnprocessable("room file must be a CSV or XLSX file")
+	ErrRoomFileUnreadable     = errConstant.NewUnprocessable("room file could not be read")
+	ErrRoomFileEmpty          = errConstant.NewUnprocessable("room file has no rows")
+	ErrRoomFileMissingColumns = errConstant.NewUnprocessable("room file must have code, name, capacity and description columns")
+	ErrRoomLibraryRequired    = errConstant.NewUnprocessable("library is required to import rooms")
 )
-
-var RoomErrors = []error{
-	ErrRoomNotFound,
-}
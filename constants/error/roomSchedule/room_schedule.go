@@ -1,13 +1,36 @@
 package error
 
-import "errors"
+import (
+	"fmt"
+	"net/http"
+	"room-service/constants"
+	errConstant "room-service/constants/error"
+)
 
 var (
-	ErrRoomScheduleNotFound = errors.New("room schedule not found")
-	ErrRoomScheduleIsExist  = errors.New("room schedule already exist")
+	ErrRoomScheduleNotFound      = errConstant.NewNotFound("room schedule not found")
+	ErrRoomScheduleIsExist       = errConstant.NewConflict("room schedule already exist")
+	ErrRoomScheduleNotAvailable  = errConstant.NewConflict("room schedule is not available")
+	ErrRoomScheduleAlreadyBooked = errConstant.NewConflict("room schedule already booked")
+	ErrRoomScheduleInvalidStatus = errConstant.NewUnprocessable("invalid room schedule status")
+	ErrInvalidDateRange          = errConstant.NewUnprocessable("invalid date range")
+	ErrRoomScheduleOutsideHours  = errConstant.NewUnprocessable("time is outside the room's opening hours")
+	ErrRoomScheduleStarted       = errConstant.NewConflict("room schedule has already started")
+	ErrRoomScheduleHoldLimit     = errConstant.NewUnprocessable("you are holding too many room schedules")
+	ErrRoomScheduleNotMovable    = errConstant.NewConflict("only available or blocked room schedules can be moved")
 )
 
-var RoomScheduleErrors = []error{
-	ErrRoomScheduleNotFound,
-	ErrRoomScheduleIsExist,
+// InvalidStatusTransitionError is returned when a room schedule is asked to
+// move to a status its current status does not allow.
+type InvalidStatusTransitionError struct {
+	From constants.RoomScheduleStatusName
+	To   constants.RoomScheduleStatusName
+}
+
+func (e *InvalidStatusTransitionError) Error() string {
+	return fmt.Sprintf("room schedule status cannot change from %s to %s", e.From, e.To)
+}
+
+func (e *InvalidStatusTransitionError) HTTPStatus() int {
+	return http.StatusConflict
 }
@@ -1,11 +1,11 @@
 package error
 
-import "errors"
+import errConstant "room-service/constants/error"
 
 var (
-	ErrTimeNotFound = errors.New("time not found")
+	ErrTimeNotFound          = errConstant.NewNotFound("time not found")
+	ErrInvalidTimeRange      = errConstant.NewUnprocessable("start time must be before end time")
+	ErrTimeOverlap           = errConstant.NewConflict("time overlaps an existing time slot")
+	ErrTimeInUse             = errConstant.NewConflict("time is used by room schedules")
+	ErrTimeHasActiveBookings = errConstant.NewConflict("time has schedules with active bookings")
 )
-
-var TimeErrors = []error{
-	ErrTimeNotFound,
-}
@@ -0,0 +1,14 @@
+package error
+
+import errConstant "room-service/constants/error"
+
+var (
+	ErrWaitlistNotFound       = errConstant.NewNotFound("waitlist entry not found")
+	ErrWaitlistAlreadyJoined  = errConstant.NewConflict("you are already on the waitlist for this schedule")
+	ErrWaitlistScheduleOpen   = errConstant.NewConflict("room schedule is available, book it instead")
+	ErrWaitlistOwnBooking     = errConstant.NewConflict("you have already booked this schedule")
+	ErrWaitlistNotOffered     = errConstant.NewConflict("waitlist entry has no open offer")
+	ErrWaitlistOfferExpired   = errConstant.NewConflict("waitlist offer has expired")
+	ErrWaitlistNotCancellable = errConstant.NewConflict("waitlist entry can no longer be cancelled")
+	ErrWaitlistSchedulePassed = errConstant.NewConflict("room schedule has already started")
+)
@@ -0,0 +1,8 @@
+package constants
+
+type StrikeReason string
+
+const (
+	StrikeNoShow           StrikeReason = "NoShow"
+	StrikeLateCancellation StrikeReason = "LateCancellation"
+)
@@ -0,0 +1,20 @@
+package constants
+
+// Actions reported for the rows of a room import.
+const (
+	RoomImportCreate  = "create"
+	RoomImportUpdate  = "update"
+	RoomImportInvalid = "invalid"
+)
+
+// RoomFileColumns are the columns of room import and export files, in the
+// order they are exported.
+var RoomFileColumns = []string{
+	"code",
+	"name",
+	"capacity",
+	"description",
+	"requiresApproval",
+	"images",
+	"amenities",
+}
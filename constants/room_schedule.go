@@ -6,19 +6,75 @@ type RoomScheduleStatus int
 const (
 	Available RoomScheduleStatus = 100
 	Booked    RoomScheduleStatus = 200
+	Pending   RoomScheduleStatus = 300
+	Approved  RoomScheduleStatus = 400
+	Rejected  RoomScheduleStatus = 500
+	CheckedIn RoomScheduleStatus = 600
+	NoShow    RoomScheduleStatus = 700
+	Blocked   RoomScheduleStatus = 800
+	Held      RoomScheduleStatus = 900
 
 	AvailableString RoomScheduleStatusName = "Available"
 	BookedString    RoomScheduleStatusName = "Booked"
+	PendingString   RoomScheduleStatusName = "Pending"
+	ApprovedString  RoomScheduleStatusName = "Approved"
+	RejectedString  RoomScheduleStatusName = "Rejected"
+	CheckedInString RoomScheduleStatusName = "CheckedIn"
+	NoShowString    RoomScheduleStatusName = "NoShow"
+	BlockedString   RoomScheduleStatusName = "Blocked"
+	HeldString      RoomScheduleStatusName = "Held"
+
+	// EmptyString marks a calendar cell that has no schedule.
+	EmptyString RoomScheduleStatusName = "Empty"
+)
+
+const (
+	GenerateModeSkip = "skip"
+	GenerateModeFail = "fail"
+)
+
+const (
+	CalendarViewWeek  = "week"
+	CalendarViewMonth = "month"
 )
 
 var mapRoomScheduleStatusIntToString = map[RoomScheduleStatus]RoomScheduleStatusName{
 	Available: AvailableString,
 	Booked:    BookedString,
+	Pending:   PendingString,
+	Approved:  ApprovedString,
+	Rejected:  RejectedString,
+	CheckedIn: CheckedInString,
+	NoShow:    NoShowString,
+	Blocked:   BlockedString,
+	Held:      HeldString,
 }
 
 var mapRoomScheduleStatusStringToInt = map[RoomScheduleStatusName]RoomScheduleStatus{
 	AvailableString: Available,
 	BookedString:    Booked,
+	PendingString:   Pending,
+	ApprovedString:  Approved,
+	RejectedString:  Rejected,
+	CheckedInString: CheckedIn,
+	NoShowString:    NoShow,
+	BlockedString:   Blocked,
+	HeldString:      Held,
+}
+
+// roomScheduleTransitions lists, for every status, the statuses a schedule
+// may move to next. CheckedIn and NoShow are final. A Held slot is reserved
+// for one user until it is booked or the hold runs out.
+var roomScheduleTransitions = map[RoomScheduleStatus][]RoomScheduleStatus{
+	Available: {Booked, Pending, Blocked, Held},
+	Booked:    {Available, CheckedIn, NoShow, Blocked},
+	Pending:   {Approved, Rejected, Available, Blocked},
+	Approved:  {Available, CheckedIn, NoShow, Blocked},
+	Rejected:  {Available},
+	CheckedIn: {},
+	NoShow:    {},
+	Blocked:   {Available},
+	Held:      {Available, Booked, Pending, Blocked},
 }
 
 func (r RoomScheduleStatus) GetStatusString() RoomScheduleStatusName {
@@ -28,3 +84,21 @@ func (r RoomScheduleStatus) GetStatusString() RoomScheduleStatusName {
 func (r RoomScheduleStatusName) GetStatusInt() RoomScheduleStatus {
 	return mapRoomScheduleStatusStringToInt[r]
 }
+
+func (r RoomScheduleStatus) CanTransitionTo(next RoomScheduleStatus) bool {
+	for _, status := range roomScheduleTransitions[r] {
+		if status == next {
+			return true
+		}
+	}
+	return false
+}
+
+// BookedStatuses lists the statuses for which IsBooked is true.
+var BookedStatuses = []RoomScheduleStatus{Booked, Pending, Approved, CheckedIn}
+
+// IsBooked reports whether the slot is taken by a booking, whether or not
+// the booking has been approved yet.
+func (r RoomScheduleStatus) IsBooked() bool {
+	return r == Booked || r == Pending || r == Approved || r == CheckedIn
+}
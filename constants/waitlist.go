@@ -0,0 +1,42 @@
+package constants
+
+type WaitlistStatusName string
+type WaitlistStatus int
+
+const (
+	WaitlistWaiting   WaitlistStatus = 100
+	WaitlistOffered   WaitlistStatus = 200
+	WaitlistAccepted  WaitlistStatus = 300
+	WaitlistExpired   WaitlistStatus = 400
+	WaitlistCancelled WaitlistStatus = 500
+
+	WaitlistWaitingString   WaitlistStatusName = "Waiting"
+	WaitlistOfferedString   WaitlistStatusName = "Offered"
+	WaitlistAcceptedString  WaitlistStatusName = "Accepted"
+	WaitlistExpiredString   WaitlistStatusName = "Expired"
+	WaitlistCancelledString WaitlistStatusName = "Cancelled"
+)
+
+var mapWaitlistStatusIntToString = map[WaitlistStatus]WaitlistStatusName{
+	WaitlistWaiting:   WaitlistWaitingString,
+	WaitlistOffered:   WaitlistOfferedString,
+	WaitlistAccepted:  WaitlistAcceptedString,
+	WaitlistExpired:   WaitlistExpiredString,
+	WaitlistCancelled: WaitlistCancelledString,
+}
+
+var mapWaitlistStatusStringToInt = map[WaitlistStatusName]WaitlistStatus{
+	WaitlistWaitingString:   WaitlistWaiting,
+	WaitlistOfferedString:   WaitlistOffered,
+	WaitlistAcceptedString:  WaitlistAccepted,
+	WaitlistExpiredString:   WaitlistExpired,
+	WaitlistCancelledString: WaitlistCancelled,
+}
+
+func (w WaitlistStatus) GetStatusString() WaitlistStatusName {
+	return mapWaitlistStatusIntToString[w]
+}
+
+func (w WaitlistStatusName) GetStatusInt() WaitlistStatus {
+	return mapWaitlistStatusStringToInt[w]
+}
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"net/http"
+	errValidation "room-service/common/error"
+	"room-service/common/response"
+	errConstant "room-service/constants/error"
+	"room-service/domain/dto"
+	"room-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type AmenityController struct {
+	service services.IServiceRegistry
+}
+
+type IAmenityController interface {
+	GetAll(*gin.Context)
+	GetByUUID(*gin.Context)
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+}
+
+func NewAmenityController(service services.IServiceRegistry) IAmenityController {
+	return &AmenityController{service: service}
+}
+
+func (a *AmenityController) GetAll(c *gin.Context) {
+	result, err := a.service.GetAmenity().GetAll(c)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (a *AmenityController) GetByUUID(c *gin.Context) {
+	result, err := a.service.GetAmenity().GetByUUID(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (a *AmenityController) bindRequest(c *gin.Context) (*dto.AmenityRequest, bool) {
+	var request dto.AmenityRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return nil, false
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return nil, false
+	}
+
+	return &request, true
+}
+
+func (a *AmenityController) Create(c *gin.Context) {
+	request, ok := a.bindRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := a.service.GetAmenity().Create(c, request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (a *AmenityController) Update(c *gin.Context) {
+	request, ok := a.bindRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := a.service.GetAmenity().Update(c, c.Param("uuid"), request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (a *AmenityController) Delete(c *gin.Context) {
+	err := a.service.GetAmenity().Delete(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
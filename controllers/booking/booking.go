@@ -0,0 +1,518 @@
+package controllers
+
+import (
+	"net/http"
+	errValidation "room-service/common/error"
+	"room-service/common/response"
+	errConstant "room-service/constants/error"
+	"room-service/domain/dto"
+	"room-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type BookingController struct {
+	service services.IServiceRegistry
+}
+
+type IBookingController interface {
+	GetAllWithPagination(*gin.Context)
+	GetAllByUser(*gin.Context)
+	GetAllPending(*gin.Context)
+	GetByUUID(*gin.Context)
+	Create(*gin.Context)
+	Cancel(*gin.Context)
+	CancelByAdmin(*gin.Context)
+	Review(*gin.Context)
+	CheckIn(*gin.Context)
+	CheckInByRoom(*gin.Context)
+	GetAllSeriesByUser(*gin.Context)
+	GetSeriesByUUID(*gin.Context)
+	CreateSeries(*gin.Context)
+	CancelSeries(*gin.Context)
+	CancelSeriesByAdmin(*gin.Context)
+}
+
+func NewBookingController(service services.IServiceRegistry) IBookingController {
+	return &BookingController{service: service}
+}
+
+func (b *BookingController) bindParams(c *gin.Context) (*dto.BookingRequestParam, bool) {
+	var params dto.BookingRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return nil, false
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return nil, false
+	}
+
+	return &params, true
+}
+
+func (b *BookingController) GetAllWithPagination(c *gin.Context) {
+	params, ok := b.bindParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := b.service.GetBooking().GetAllWithPagination(c, params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingController) GetAllByUser(c *gin.Context) {
+	params, ok := b.bindParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := b.service.GetBooking().GetAllByUser(c, params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingController) GetAllPending(c *gin.Context) {
+	params, ok := b.bindParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := b.service.GetBooking().GetAllPending(c, params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingController) GetByUUID(c *gin.Context) {
+	result, err := b.service.GetBooking().GetByUUID(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingController) Create(c *gin.Context) {
+	var request dto.BookingRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := b.service.GetBooking().Create(c, &request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingController) bindCancelRequest(c *gin.Context) (*dto.CancelBookingRequest, bool) {
+	var request dto.CancelBookingRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return nil, false
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return nil, false
+	}
+
+	return &request, true
+}
+
+func (b *BookingController) Cancel(c *gin.Context) {
+	request, ok := b.bindCancelRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := b.service.GetBooking().Cancel(c, c.Param("uuid"), request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingController) CancelByAdmin(c *gin.Context) {
+	request, ok := b.bindCancelRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := b.service.GetBooking().CancelByAdmin(c, c.Param("uuid"), request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingController) Review(c *gin.Context) {
+	var request dto.ReviewBookingRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := b.service.GetBooking().Review(c, &request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingController) CheckIn(c *gin.Context) {
+	result, err := b.service.GetBooking().CheckIn(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingController) CheckInByRoom(c *gin.Context) {
+	var request dto.CheckInByRoomRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := b.service.GetBooking().CheckInByRoom(c, c.Param("uuid"), &request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingController) GetAllSeriesByUser(c *gin.Context) {
+	result, err := b.service.GetBooking().GetAllSeriesByUser(c)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingController) GetSeriesByUUID(c *gin.Context) {
+	result, err := b.service.GetBooking().GetSeriesByUUID(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingController) bindSeriesRequest(c *gin.Context) (*dto.BookingSeriesRequest, bool) {
+	var request dto.BookingSeriesRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return nil, false
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return nil, false
+	}
+
+	return &request, true
+}
+
+func (b *BookingController) CreateSeries(c *gin.Context) {
+	request, ok := b.bindSeriesRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := b.service.GetBooking().CreateSeries(c, request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingController) bindCancelSeriesRequest(c *gin.Context) (*dto.CancelBookingSeriesRequest, bool) {
+	var request dto.CancelBookingSeriesRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return nil, false
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return nil, false
+	}
+
+	return &request, true
+}
+
+func (b *BookingController) CancelSeries(c *gin.Context) {
+	request, ok := b.bindCancelSeriesRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := b.service.GetBooking().CancelSeries(c, c.Param("uuid"), request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingController) CancelSeriesByAdmin(c *gin.Context) {
+	request, ok := b.bindCancelSeriesRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := b.service.GetBooking().CancelSeriesByAdmin(c, c.Param("uuid"), request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"net/http"
+	errValidation "room-service/common/error"
+	"room-service/common/response"
+	errConstant "room-service/constants/error"
+	"room-service/domain/dto"
+	"room-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type BookingQuotaController struct {
+	service services.IServiceRegistry
+}
+
+type IBookingQuotaController interface {
+	GetAll(*gin.Context)
+	GetByUUID(*gin.Context)
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+}
+
+func NewBookingQuotaController(service services.IServiceRegistry) IBookingQuotaController {
+	return &BookingQuotaController{service: service}
+}
+
+func (b *BookingQuotaController) GetAll(c *gin.Context) {
+	result, err := b.service.GetBookingQuota().GetAll(c)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingQuotaController) GetByUUID(c *gin.Context) {
+	result, err := b.service.GetBookingQuota().GetByUUID(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingQuotaController) bindRequest(c *gin.Context) (*dto.BookingQuotaRequest, bool) {
+	var request dto.BookingQuotaRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return nil, false
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return nil, false
+	}
+
+	return &request, true
+}
+
+func (b *BookingQuotaController) Create(c *gin.Context) {
+	request, ok := b.bindRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := b.service.GetBookingQuota().Create(c, request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingQuotaController) Update(c *gin.Context) {
+	request, ok := b.bindRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := b.service.GetBookingQuota().Update(c, c.Param("uuid"), request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (b *BookingQuotaController) Delete(c *gin.Context) {
+	err := b.service.GetBookingQuota().Delete(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
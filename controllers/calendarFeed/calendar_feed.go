@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	errValidation "room-service/common/error"
+	"room-service/common/response"
+	errConstant "room-service/constants/error"
+	"room-service/domain/dto"
+	"room-service/services"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type CalendarFeedController struct {
+	service services.IServiceRegistry
+}
+
+type ICalendarFeedController interface {
+	GetAllByUser(*gin.Context)
+	Create(*gin.Context)
+	Revoke(*gin.Context)
+	Render(*gin.Context)
+}
+
+func NewCalendarFeedController(service services.IServiceRegistry) ICalendarFeedController {
+	return &CalendarFeedController{service: service}
+}
+
+// setURL fills in the address calendar apps subscribe to, built from the
+// request since feeds are served under the same path as the feed list.
+func (f *CalendarFeedController) setURL(c *gin.Context, feed *dto.CalendarFeedResponse) {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	feed.URL = fmt.Sprintf("%s://%s%s/%s.ics?token=%s", scheme, c.Request.Host, c.FullPath(), feed.UUID, feed.Token)
+}
+
+func (f *CalendarFeedController) GetAllByUser(c *gin.Context) {
+	result, err := f.service.GetCalendarFeed().GetAllByUser(c)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	for i := range result {
+		f.setURL(c, &result[i])
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *CalendarFeedController) Create(c *gin.Context) {
+	var request dto.CalendarFeedRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetCalendarFeed().Create(c, &request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	f.setURL(c, result)
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *CalendarFeedController) Revoke(c *gin.Context) {
+	err := f.service.GetCalendarFeed().Revoke(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
+
+func (f *CalendarFeedController) Render(c *gin.Context) {
+	uuid := strings.TrimSuffix(c.Param("uuid"), ".ics")
+	result, err := f.service.GetCalendarFeed().Render(c, uuid, c.Query("token"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s.ics"`, uuid))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", result)
+}
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"net/http"
+	errValidation "room-service/common/error"
+	"room-service/common/response"
+	errConstant "room-service/constants/error"
+	"room-service/domain/dto"
+	"room-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type ClosureController struct {
+	service services.IServiceRegistry
+}
+
+type IClosureController interface {
+	GetAll(*gin.Context)
+	GetByUUID(*gin.Context)
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+}
+
+func NewClosureController(service services.IServiceRegistry) IClosureController {
+	return &ClosureController{service: service}
+}
+
+func (cl *ClosureController) GetAll(c *gin.Context) {
+	result, err := cl.service.GetClosure().GetAll(c)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (cl *ClosureController) GetByUUID(c *gin.Context) {
+	result, err := cl.service.GetClosure().GetByUUID(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (cl *ClosureController) bindRequest(c *gin.Context) (*dto.ClosureRequest, bool) {
+	var request dto.ClosureRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return nil, false
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return nil, false
+	}
+
+	return &request, true
+}
+
+func (cl *ClosureController) Create(c *gin.Context) {
+	request, ok := cl.bindRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := cl.service.GetClosure().Create(c, request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (cl *ClosureController) Update(c *gin.Context) {
+	request, ok := cl.bindRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := cl.service.GetClosure().Update(c, c.Param("uuid"), request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (cl *ClosureController) Delete(c *gin.Context) {
+	err := cl.service.GetClosure().Delete(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
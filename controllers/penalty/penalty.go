@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"net/http"
+	errValidation "room-service/common/error"
+	"room-service/common/response"
+	errConstant "room-service/constants/error"
+	"room-service/domain/dto"
+	"room-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type PenaltyController struct {
+	service services.IServiceRegistry
+}
+
+type IPenaltyController interface {
+	GetMine(*gin.Context)
+	GetStrikes(*gin.Context)
+	ClearStrike(*gin.Context)
+	GetSuspensions(*gin.Context)
+	LiftSuspension(*gin.Context)
+}
+
+func NewPenaltyController(service services.IServiceRegistry) IPenaltyController {
+	return &PenaltyController{service: service}
+}
+
+// bindParam binds and validates the listing filter, writing the error
+// response itself when it fails.
+func (p *PenaltyController) bindParam(c *gin.Context) (*dto.PenaltyRequestParam, bool) {
+	var params dto.PenaltyRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return nil, false
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return nil, false
+	}
+
+	return &params, true
+}
+
+func (p *PenaltyController) GetMine(c *gin.Context) {
+	result, err := p.service.GetPenalty().GetMine(c)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (p *PenaltyController) GetStrikes(c *gin.Context) {
+	params, ok := p.bindParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := p.service.GetPenalty().GetStrikes(c, params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (p *PenaltyController) ClearStrike(c *gin.Context) {
+	result, err := p.service.GetPenalty().ClearStrike(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (p *PenaltyController) GetSuspensions(c *gin.Context) {
+	params, ok := p.bindParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := p.service.GetPenalty().GetSuspensions(c, params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (p *PenaltyController) LiftSuspension(c *gin.Context) {
+	result, err := p.service.GetPenalty().LiftSuspension(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
@@ -1,9 +1,17 @@
 package controllers
 
 import (
+	amenityController "room-service/controllers/amenity"
+	bookingController "room-service/controllers/booking"
+	bookingQuotaController "room-service/controllers/bookingQuota"
+	calendarFeedController "room-service/controllers/calendarFeed"
+	closureController "room-service/controllers/closure"
+	penaltyController "room-service/controllers/penalty"
 	controllers "room-service/controllers/room"
 	controllers2 "room-service/controllers/roomSchedule"
+	roomSlotTemplateController "room-service/controllers/roomSlotTemplate"
 	controllers3 "room-service/controllers/time"
+	waitlistController "room-service/controllers/waitlist"
 	"room-service/services"
 )
 
@@ -12,15 +20,47 @@ type Registry struct {
 }
 
 type IControllerRegistry interface {
+	GetAmenity() amenityController.IAmenityController
+	GetBooking() bookingController.IBookingController
+	GetBookingQuota() bookingQuotaController.IBookingQuotaController
+	GetCalendarFeed() calendarFeedController.ICalendarFeedController
+	GetClosure() closureController.IClosureController
+	GetPenalty() penaltyController.IPenaltyController
 	GetRoom() controllers.IRoomController
 	GetRoomSchedule() controllers2.IRoomScheduleController
+	GetRoomSlotTemplate() roomSlotTemplateController.IRoomSlotTemplateController
 	GetTime() controllers3.ITimeController
+	GetWaitlist() waitlistController.IWaitlistController
 }
 
 func NewControllerRegistry(service services.IServiceRegistry) IControllerRegistry {
 	return &Registry{service: service}
 }
 
+func (r *Registry) GetAmenity() amenityController.IAmenityController {
+	return amenityController.NewAmenityController(r.service)
+}
+
+func (r *Registry) GetBooking() bookingController.IBookingController {
+	return bookingController.NewBookingController(r.service)
+}
+
+func (r *Registry) GetBookingQuota() bookingQuotaController.IBookingQuotaController {
+	return bookingQuotaController.NewBookingQuotaController(r.service)
+}
+
+func (r *Registry) GetCalendarFeed() calendarFeedController.ICalendarFeedController {
+	return calendarFeedController.NewCalendarFeedController(r.service)
+}
+
+func (r *Registry) GetClosure() closureController.IClosureController {
+	return closureController.NewClosureController(r.service)
+}
+
+func (r *Registry) GetPenalty() penaltyController.IPenaltyController {
+	return penaltyController.NewPenaltyController(r.service)
+}
+
 func (r *Registry) GetRoom() controllers.IRoomController {
 	return controllers.NewRoomController(r.service)
 }
@@ -29,6 +69,14 @@ func (r *Registry) GetRoomSchedule() controllers2.IRoomScheduleController {
 	return controllers2.NewRoomScheduleController(r.service)
 }
 
+func (r *Registry) GetRoomSlotTemplate() roomSlotTemplateController.IRoomSlotTemplateController {
+	return roomSlotTemplateController.NewRoomSlotTemplateController(r.service)
+}
+
 func (r *Registry) GetTime() controllers3.ITimeController {
 	return controllers3.NewTimeController(r.service)
 }
+
+func (r *Registry) GetWaitlist() waitlistController.IWaitlistController {
+	return waitlistController.NewWaitlistController(r.service)
+}
@@ -1,9 +1,11 @@
 package controllers
 
 import (
+	"fmt"
 	"net/http"
 	errValidation "room-service/common/error"
 	"room-service/common/response"
+	errConstant "room-service/constants/error"
 	"room-service/domain/dto"
 	"room-service/services"
 
@@ -23,6 +25,10 @@ type IRoomController interface {
 	Create(*gin.Context)
 	Update(*gin.Context)
 	Delete(*gin.Context)
+	GetCheckInToken(*gin.Context)
+	RotateCheckInToken(*gin.Context)
+	Import(*gin.Context)
+	Export(*gin.Context)
 }
 
 func NewRoomController(service services.IServiceRegistry) IRoomController {
@@ -34,9 +40,8 @@ func (f *RoomController) GetAllWithPagination(c *gin.Context) {
 	err := c.ShouldBindQuery(&params)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
 		})
 		return
 	}
@@ -47,7 +52,6 @@ func (f *RoomController) GetAllWithPagination(c *gin.Context) {
 		errMessage := http.StatusText(http.StatusUnprocessableEntity)
 		errResponse := errValidation.ErrValidationResponse(err)
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code:    http.StatusBadRequest,
 			Err:     err,
 			Message: &errMessage,
 			Data:    errResponse,
@@ -59,9 +63,8 @@ func (f *RoomController) GetAllWithPagination(c *gin.Context) {
 	result, err := f.service.GetRoom().GetAllWithPagination(c, &params)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
@@ -74,12 +77,35 @@ func (f *RoomController) GetAllWithPagination(c *gin.Context) {
 }
 
 func (f *RoomController) GetAllWithoutPagination(c *gin.Context) {
-	result, err := f.service.GetRoom().GetAllWithoutPagination(c)
+	var params dto.RoomFilterParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetRoom().GetAllWithoutPagination(c, &params)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
@@ -95,9 +121,8 @@ func (f *RoomController) GetByUUID(c *gin.Context) {
 	result, err := f.service.GetRoom().GetByUUID(c, c.Param("uuid"))
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
@@ -115,9 +140,8 @@ func (f *RoomController) Create(c *gin.Context) {
 
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
 		})
 		return
 	}
@@ -128,7 +152,6 @@ func (f *RoomController) Create(c *gin.Context) {
 		errMessage := http.StatusText(http.StatusUnprocessableEntity)
 		errResponse := errValidation.ErrValidationResponse(err)
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code:    http.StatusBadRequest,
 			Err:     err,
 			Message: &errMessage,
 			Data:    errResponse,
@@ -140,9 +163,8 @@ func (f *RoomController) Create(c *gin.Context) {
 	result, err := f.service.GetRoom().Create(c, &request)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
@@ -160,9 +182,8 @@ func (f *RoomController) Update(c *gin.Context) {
 
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
 		})
 		return
 	}
@@ -173,7 +194,6 @@ func (f *RoomController) Update(c *gin.Context) {
 		errMessage := http.StatusText(http.StatusUnprocessableEntity)
 		errResponse := errValidation.ErrValidationResponse(err)
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code:    http.StatusBadRequest,
 			Err:     err,
 			Message: &errMessage,
 			Data:    errResponse,
@@ -185,9 +205,8 @@ func (f *RoomController) Update(c *gin.Context) {
 	result, err := f.service.GetRoom().Update(c, c.Param("uuid"), &requestUpdate)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
@@ -203,15 +222,127 @@ func (f *RoomController) Delete(c *gin.Context) {
 	err := f.service.GetRoom().Delete(c, c.Param("uuid"))
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
+
+func (f *RoomController) GetCheckInToken(c *gin.Context) {
+	result, err := f.service.GetRoom().GetCheckInToken(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *RoomController) RotateCheckInToken(c *gin.Context) {
+	result, err := f.service.GetRoom().RotateCheckInToken(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *RoomController) Import(c *gin.Context) {
+	var request dto.RoomImportRequest
+	err := c.ShouldBindWith(&request, binding.FormMultipart)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetRoom().Import(c, &request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
 
 	response.HTTPResponse(response.ParamHTTPResp{
 		Code: http.StatusOK,
+		Data: result,
 		Gin:  c,
 	})
 }
+
+func (f *RoomController) Export(c *gin.Context) {
+	var params dto.RoomExportParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetRoom().Export(c, &params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, result.Name))
+	c.Data(http.StatusOK, result.ContentType, result.Data)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	errValidation "room-service/common/error"
 	"room-service/common/response"
+	errConstant "room-service/constants/error"
 	"room-service/domain/dto"
 	"room-service/services"
 
@@ -19,11 +20,16 @@ type IRoomScheduleController interface {
 	GetAllWithPagination(c *gin.Context)
 	GetAllByRoomIDAndDate(c *gin.Context)
 	GetByUUID(c *gin.Context)
+	SearchAvailability(c *gin.Context)
+	GetCalendar(c *gin.Context)
 	Create(c *gin.Context)
 	Update(c *gin.Context)
 	UpdateStatus(c *gin.Context)
 	Delete(c *gin.Context)
 	GenerateScheduleForOneMonth(c *gin.Context)
+	GenerateSchedule(c *gin.Context)
+	Hold(c *gin.Context)
+	Release(c *gin.Context)
 }
 
 func NewRoomScheduleController(service services.IServiceRegistry) IRoomScheduleController {
@@ -35,9 +41,8 @@ func (f *roomScheduleController) GetAllWithPagination(c *gin.Context) {
 	err := c.ShouldBindQuery(&params)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
 		})
 		return
 	}
@@ -48,7 +53,6 @@ func (f *roomScheduleController) GetAllWithPagination(c *gin.Context) {
 		errMessage := http.StatusText(http.StatusUnprocessableEntity)
 		errResponse := errValidation.ErrValidationResponse(err)
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code:    http.StatusBadRequest,
 			Err:     err,
 			Message: &errMessage,
 			Data:    errResponse,
@@ -60,9 +64,8 @@ func (f *roomScheduleController) GetAllWithPagination(c *gin.Context) {
 	result, err := f.service.GetRoomSchedule().GetAllWithPagination(c, &params)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
@@ -81,8 +84,7 @@ func (f *roomScheduleController) GetAllByRoomIDAndDate(c *gin.Context) {
 		errMessage := http.StatusText(http.StatusUnprocessableEntity)
 		errResponse := errValidation.ErrValidationResponse(err)
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code:    http.StatusBadRequest,
-			Err:     err,
+			Err:     errConstant.InvalidRequest(err),
 			Message: &errMessage,
 			Data:    errResponse,
 			Gin:     c,
@@ -93,9 +95,90 @@ func (f *roomScheduleController) GetAllByRoomIDAndDate(c *gin.Context) {
 	result, err := f.service.GetRoomSchedule().GetAllByRoomIDAndDate(c, c.Param("uuid"), params.Date)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *roomScheduleController) SearchAvailability(c *gin.Context) {
+	var params dto.AvailabilitySearchParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetRoomSchedule().SearchAvailability(c, &params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *roomScheduleController) GetCalendar(c *gin.Context) {
+	var params dto.CalendarRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetRoomSchedule().GetCalendar(c, &params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
@@ -111,9 +194,8 @@ func (f *roomScheduleController) GetByUUID(c *gin.Context) {
 	result, err := f.service.GetRoomSchedule().GetByUUID(c, c.Param("uuid"))
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
@@ -130,9 +212,8 @@ func (f *roomScheduleController) Create(c *gin.Context) {
 	err := c.ShouldBindJSON(&params)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
 		})
 		return
 	}
@@ -143,7 +224,6 @@ func (f *roomScheduleController) Create(c *gin.Context) {
 		errMessage := http.StatusText(http.StatusUnprocessableEntity)
 		errResponse := errValidation.ErrValidationResponse(err)
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code:    http.StatusBadRequest,
 			Err:     err,
 			Message: &errMessage,
 			Data:    errResponse,
@@ -155,9 +235,8 @@ func (f *roomScheduleController) Create(c *gin.Context) {
 	err = f.service.GetRoomSchedule().Create(c, &params)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
@@ -173,9 +252,8 @@ func (f *roomScheduleController) GenerateScheduleForOneMonth(c *gin.Context) {
 	err := c.ShouldBindJSON(&params)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
 		})
 		return
 	}
@@ -186,7 +264,6 @@ func (f *roomScheduleController) GenerateScheduleForOneMonth(c *gin.Context) {
 		errMessage := http.StatusText(http.StatusUnprocessableEntity)
 		errResponse := errValidation.ErrValidationResponse(err)
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code:    http.StatusBadRequest,
 			Err:     err,
 			Message: &errMessage,
 			Data:    errResponse,
@@ -198,15 +275,55 @@ func (f *roomScheduleController) GenerateScheduleForOneMonth(c *gin.Context) {
 	err = f.service.GetRoomSchedule().GenerateScheduleForOneMonth(c, &params)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Gin:  c,
+	})
+}
+
+func (f *roomScheduleController) GenerateSchedule(c *gin.Context) {
+	var params dto.GenerateRoomScheduleRequest
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetRoomSchedule().GenerateSchedule(c, &params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
 
 	response.HTTPResponse(response.ParamHTTPResp{
 		Code: http.StatusCreated,
+		Data: result,
 		Gin:  c,
 	})
 }
@@ -216,9 +333,8 @@ func (f *roomScheduleController) Update(c *gin.Context) {
 	err := c.ShouldBindJSON(&params)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
 		})
 		return
 	}
@@ -229,7 +345,6 @@ func (f *roomScheduleController) Update(c *gin.Context) {
 		errMessage := http.StatusText(http.StatusUnprocessableEntity)
 		errResponse := errValidation.ErrValidationResponse(err)
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code:    http.StatusBadRequest,
 			Err:     err,
 			Message: &errMessage,
 			Data:    errResponse,
@@ -241,9 +356,8 @@ func (f *roomScheduleController) Update(c *gin.Context) {
 	result, err := f.service.GetRoomSchedule().Update(c, c.Param("uuid"), &params)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
@@ -260,9 +374,8 @@ func (f *roomScheduleController) UpdateStatus(c *gin.Context) {
 	err := c.ShouldBindJSON(&request)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
 		})
 		return
 	}
@@ -273,7 +386,6 @@ func (f *roomScheduleController) UpdateStatus(c *gin.Context) {
 		errMessage := http.StatusText(http.StatusUnprocessableEntity)
 		errResponse := errValidation.ErrValidationResponse(err)
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code:    http.StatusBadRequest,
 			Err:     err,
 			Message: &errMessage,
 			Data:    errResponse,
@@ -282,18 +394,18 @@ func (f *roomScheduleController) UpdateStatus(c *gin.Context) {
 		return
 	}
 
-	err = f.service.GetRoomSchedule().UpdateStatus(c, &request)
+	result, err := f.service.GetRoomSchedule().UpdateStatus(c, c.Param("uuid"), &request)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
 
 	response.HTTPResponse(response.ParamHTTPResp{
-		Code: http.StatusCreated,
+		Code: http.StatusOK,
+		Data: result,
 		Gin:  c,
 	})
 }
@@ -302,9 +414,79 @@ func (f *roomScheduleController) Delete(c *gin.Context) {
 	err := f.service.GetRoomSchedule().Delete(c, c.Param("uuid"))
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
+
+func (f *roomScheduleController) bindHoldRequest(c *gin.Context) (*dto.HoldRoomScheduleRequest, bool) {
+	var params dto.HoldRoomScheduleRequest
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return nil, false
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return nil, false
+	}
+
+	return &params, true
+}
+
+func (f *roomScheduleController) Hold(c *gin.Context) {
+	params, ok := f.bindHoldRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := f.service.GetRoomSchedule().Hold(c, params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *roomScheduleController) Release(c *gin.Context) {
+	params, ok := f.bindHoldRequest(c)
+	if !ok {
+		return
+	}
+
+	err := f.service.GetRoomSchedule().Release(c, params)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
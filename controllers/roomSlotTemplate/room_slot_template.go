@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"net/http"
+	errValidation "room-service/common/error"
+	"room-service/common/response"
+	errConstant "room-service/constants/error"
+	"room-service/domain/dto"
+	"room-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type RoomSlotTemplateController struct {
+	service services.IServiceRegistry
+}
+
+type IRoomSlotTemplateController interface {
+	GetByRoomID(*gin.Context)
+	Replace(*gin.Context)
+}
+
+func NewRoomSlotTemplateController(service services.IServiceRegistry) IRoomSlotTemplateController {
+	return &RoomSlotTemplateController{service: service}
+}
+
+func (r *RoomSlotTemplateController) GetByRoomID(c *gin.Context) {
+	result, err := r.service.GetRoomSlotTemplate().GetByRoomID(c, c.Param("uuid"))
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (r *RoomSlotTemplateController) Replace(c *gin.Context) {
+	var request dto.RoomSlotTemplateRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := r.service.GetRoomSlotTemplate().Replace(c, c.Param("uuid"), &request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
@@ -2,11 +2,14 @@ package controllers
 
 import (
 	"net/http"
+	errValidation "room-service/common/error"
 	"room-service/common/response"
+	errConstant "room-service/constants/error"
 	"room-service/domain/dto"
 	"room-service/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
 type TimeController struct {
@@ -17,6 +20,8 @@ type ITimeController interface {
 	GetAll(*gin.Context)
 	GetByUUID(*gin.Context)
 	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
 }
 
 func NewTimeController(service services.IServiceRegistry) ITimeController {
@@ -27,9 +32,8 @@ func (t *TimeController) GetAll(c *gin.Context) {
 	result, err := t.service.GetTime().GetAll(c)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
@@ -46,9 +50,8 @@ func (t *TimeController) GetByUUID(c *gin.Context) {
 	result, err := t.service.GetTime().GetByUUID(c, uuid)
 	if err != nil {
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
@@ -60,14 +63,45 @@ func (t *TimeController) GetByUUID(c *gin.Context) {
 	})
 }
 
-func (t *TimeController) Create(c *gin.Context) {
+func (t *TimeController) bindRequest(c *gin.Context) (*dto.TimeRequest, bool) {
 	var request dto.TimeRequest
-	result, err := t.service.GetTime().Create(c, &request)
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: errConstant.InvalidRequest(err),
+			Gin: c,
+		})
+		return nil, false
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
 	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
 		response.HTTPResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  c,
+			Err:     err,
+			Message: &errMessage,
+			Data:    errResponse,
+			Gin:     c,
+		})
+		return nil, false
+	}
+
+	return &request, true
+}
+
+func (t *TimeController) Create(c *gin.Context) {
+	request, ok := t.bindRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := t.service.GetTime().Create(c, request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
 		})
 		return
 	}
@@ -78,3 +112,41 @@ func (t *TimeController) Create(c *gin.Context) {
 		Gin:  c,
 	})
 }
+
+func (t *TimeController) Update(c *gin.Context) {
+	request, ok := t.bindRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := t.service.GetTime().Update(c, c.Param("uuid"), request)
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (t *TimeController) Delete(c *gin.Context) {
+	err := t.service.GetTime().Delete(c, c.Param("uuid"), c.Query("cascade") == "true")
+	if err != nil {
+		response.HTTPResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: c,
+		})
+		return
+	}
+
+	response.HTTPResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"room-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BookingRequest struct {
+	RoomID    string `json:"roomID" validate:"required"`
+	Date      string `json:"date" validate:"required,datetime=2006-01-02"`
+	StartTime string `json:"startTime" validate:"required,datetime=15:04"`
+	EndTime   string `json:"endTime" validate:"required,datetime=15:04"`
+	Purpose   string `json:"purpose" validate:"required,max=255"`
+	Attendees int    `json:"attendees" validate:"required,min=1"`
+}
+
+type CheckInByRoomRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type CancelBookingRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+type ReviewBookingRequest struct {
+	BookingIDs []string `json:"bookingIDs" validate:"required,min=1"`
+	Action     string   `json:"action" validate:"required,oneof=approve reject"`
+	Note       string   `json:"note" validate:"max=255"`
+}
+
+type BookingScheduleResponse struct {
+	UUID      uuid.UUID                        `json:"uuid"`
+	Date      string                           `json:"date"`
+	StartTime string                           `json:"startTime"`
+	EndTime   string                           `json:"endTime"`
+	Status    constants.RoomScheduleStatusName `json:"status"`
+}
+
+type BookingResponse struct {
+	UUID      uuid.UUID                   `json:"uuid"`
+	UserID    uuid.UUID                   `json:"userID"`
+	UserName  string                      `json:"userName"`
+	RoomID    uuid.UUID                   `json:"roomID"`
+	RoomName  string                      `json:"roomName"`
+	Purpose   string                      `json:"purpose"`
+	Attendees int                         `json:"attendees"`
+	Status    constants.BookingStatusName `json:"status"`
+	Schedules []BookingScheduleResponse   `json:"schedules"`
+
+	ReviewedAt *time.Time `json:"reviewedAt,omitempty"`
+	ReviewedBy *uuid.UUID `json:"reviewedBy,omitempty"`
+	ReviewNote string     `json:"reviewNote,omitempty"`
+
+	CheckedInAt *time.Time `json:"checkedInAt,omitempty"`
+	CheckedInBy *uuid.UUID `json:"checkedInBy,omitempty"`
+
+	CancelledAt        *time.Time `json:"cancelledAt,omitempty"`
+	CancelledBy        *uuid.UUID `json:"cancelledBy,omitempty"`
+	CancellationReason string     `json:"cancellationReason,omitempty"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	UpdatedAt          time.Time  `json:"updatedAt"`
+}
+
+type BookingRequestParam struct {
+	Page       int     `json:"page" validates:"required"`
+	Limit      int     `json:"limit" validates:"required"`
+	SortColumn *string `json:"sortColumn" validate:"omitempty,oneof=created_at updated_at status attendees user_name reviewed_at checked_in_at cancelled_at"`
+	SortOrder  *string `json:"sortOrder" validate:"omitempty,oneof=asc desc"`
+	SetOrder   *string `json:"setOrder"`
+}
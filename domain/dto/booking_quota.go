@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BookingQuotaRequest struct {
+	Role              string     `json:"role" validate:"required,oneof=Admin Co-Admin Staff Lecture User"`
+	UserID            *uuid.UUID `json:"userID"`
+	MaxHoursPerDay    int        `json:"maxHoursPerDay" validate:"min=0"`
+	MaxActiveBookings int        `json:"maxActiveBookings" validate:"min=0"`
+	MaxDaysInAdvance  int        `json:"maxDaysInAdvance" validate:"min=0"`
+	MaxNoShows        int        `json:"maxNoShows" validate:"min=0"`
+}
+
+type BookingQuotaResponse struct {
+	UUID              uuid.UUID  `json:"uuid"`
+	Role              string     `json:"role"`
+	UserID            *uuid.UUID `json:"userID"`
+	MaxHoursPerDay    int        `json:"maxHoursPerDay"`
+	MaxActiveBookings int        `json:"maxActiveBookings"`
+	MaxDaysInAdvance  int        `json:"maxDaysInAdvance"`
+	MaxNoShows        int        `json:"maxNoShows"`
+	CreatedAt         *time.Time `json:"createdAt"`
+	UpdatedAt         *time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"room-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BookingSeriesRequest struct {
+	RoomID        string `json:"roomID" validate:"required"`
+	RRule         string `json:"rrule" validate:"required,max=255"`
+	StartDate     string `json:"startDate" validate:"required,datetime=2006-01-02"`
+	EndDate       string `json:"endDate" validate:"required,datetime=2006-01-02"`
+	StartTime     string `json:"startTime" validate:"required,datetime=15:04"`
+	EndTime       string `json:"endTime" validate:"required,datetime=15:04"`
+	Purpose       string `json:"purpose" validate:"required,max=255"`
+	Attendees     int    `json:"attendees" validate:"required,min=1"`
+	SkipConflicts bool   `json:"skipConflicts"`
+}
+
+type CancelBookingSeriesRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+	Scope  string `json:"scope" validate:"required,oneof=occurrence remainder"`
+	Date   string `json:"date" validate:"required_if=Scope occurrence,omitempty,datetime=2006-01-02"`
+}
+
+type BookingSeriesOccurrenceResponse struct {
+	Date      string                      `json:"date"`
+	BookingID *uuid.UUID                  `json:"bookingID,omitempty"`
+	Status    constants.BookingStatusName `json:"status,omitempty"`
+	Conflict  string                      `json:"conflict,omitempty"`
+}
+
+type BookingSeriesResponse struct {
+	UUID        uuid.UUID                         `json:"uuid"`
+	UserID      uuid.UUID                         `json:"userID"`
+	UserName    string                            `json:"userName"`
+	RoomID      uuid.UUID                         `json:"roomID"`
+	RoomName    string                            `json:"roomName"`
+	RRule       string                            `json:"rrule"`
+	StartDate   string                            `json:"startDate"`
+	EndDate     string                            `json:"endDate"`
+	StartTime   string                            `json:"startTime"`
+	EndTime     string                            `json:"endTime"`
+	Purpose     string                            `json:"purpose"`
+	Attendees   int                               `json:"attendees"`
+	Occurrences []BookingSeriesOccurrenceResponse `json:"occurrences"`
+	CancelledAt *time.Time                        `json:"cancelledAt,omitempty"`
+	CreatedAt   *time.Time                        `json:"createdAt"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CalendarFeedRequest struct {
+	Scope  string `json:"scope" validate:"required,oneof=room user"`
+	RoomID string `json:"roomID" validate:"required_if=Scope room"`
+}
+
+type CalendarFeedResponse struct {
+	UUID      uuid.UUID  `json:"uuid"`
+	Scope     string     `json:"scope"`
+	RoomID    *uuid.UUID `json:"roomID"`
+	RoomName  string     `json:"roomName,omitempty"`
+	Token     string     `json:"token"`
+	URL       string     `json:"url"`
+	CreatedAt *time.Time `json:"createdAt"`
+}
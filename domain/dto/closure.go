@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ClosureRequest struct {
+	RoomID    string `json:"roomID"`
+	StartDate string `json:"startDate" validate:"required,datetime=2006-01-02"`
+	EndDate   string `json:"endDate" validate:"required,datetime=2006-01-02"`
+	Reason    string `json:"reason" validate:"required,max=255"`
+}
+
+type ClosureResponse struct {
+	UUID      uuid.UUID  `json:"uuid"`
+	Library   string     `json:"library"`
+	RoomID    *uuid.UUID `json:"roomID"`
+	RoomName  string     `json:"roomName,omitempty"`
+	StartDate string     `json:"startDate"`
+	EndDate   string     `json:"endDate"`
+	Reason    string     `json:"reason"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+type ClosureBookingResponse struct {
+	UUID     uuid.UUID `json:"uuid"`
+	UserID   uuid.UUID `json:"userID"`
+	UserName string    `json:"userName"`
+	RoomName string    `json:"roomName"`
+}
+
+type ClosureResultResponse struct {
+	Closure           ClosureResponse          `json:"closure"`
+	BlockedSchedules  int                      `json:"blockedSchedules"`
+	CancelledBookings []ClosureBookingResponse `json:"cancelledBookings"`
+}
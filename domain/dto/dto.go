@@ -7,8 +7,8 @@ import (
 )
 
 type TimeRequest struct {
-	StartTime string `json:"startTime" validate:"required"`
-	EndTime   string `json:"endTime" validate:"required"`
+	StartTime string `json:"startTime" validate:"required,datetime=15:04"`
+	EndTime   string `json:"endTime" validate:"required,datetime=15:04"`
 }
 
 type TimeResponse struct {
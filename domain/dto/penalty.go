@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"room-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PenaltyRequestParam struct {
+	UserID string `json:"userID" form:"userID" validate:"omitempty,uuid"`
+}
+
+type StrikeResponse struct {
+	UUID      uuid.UUID              `json:"uuid"`
+	UserID    uuid.UUID              `json:"userID"`
+	UserName  string                 `json:"userName"`
+	BookingID *uuid.UUID             `json:"bookingID"`
+	Reason    constants.StrikeReason `json:"reason"`
+	ClearedAt *time.Time             `json:"clearedAt"`
+	ClearedBy *uuid.UUID             `json:"clearedBy"`
+	CreatedAt *time.Time             `json:"createdAt"`
+}
+
+type SuspensionResponse struct {
+	UUID      uuid.UUID  `json:"uuid"`
+	UserID    uuid.UUID  `json:"userID"`
+	UserName  string     `json:"userName"`
+	Reason    string     `json:"reason"`
+	EndsAt    time.Time  `json:"endsAt"`
+	IsActive  bool       `json:"isActive"`
+	LiftedAt  *time.Time `json:"liftedAt"`
+	LiftedBy  *uuid.UUID `json:"liftedBy"`
+	CreatedAt *time.Time `json:"createdAt"`
+}
+
+type PenaltyStatusResponse struct {
+	ActiveStrikes int                 `json:"activeStrikes"`
+	Threshold     int                 `json:"threshold"`
+	Suspension    *SuspensionResponse `json:"suspension"`
+	Strikes       []StrikeResponse    `json:"strikes"`
+}
@@ -8,46 +8,115 @@ import (
 )
 
 type RoomRequest struct {
-	Name        string                 `json:"name" validate:"required"`
-	Code        string                 `json:"code" validate:"required"`
-	Capacity    string                 `json:"capacity" validate:"required"`
-	Description string                 `json:"description" validate:"required"`
-	Image       []multipart.FileHeader `json:"image" validate:"required"`
+	Name             string                 `json:"name" validate:"required"`
+	Code             string                 `json:"code" validate:"required"`
+	Capacity         int                    `json:"capacity" validate:"required,min=1"`
+	Description      string                 `json:"description" validate:"required"`
+	Image            []multipart.FileHeader `json:"image" validate:"required"`
+	RequiresApproval bool                   `json:"requiresApproval"`
+	AmenityIDs       []string               `json:"amenityIDs" form:"amenityIDs"`
 }
 
 type UpdateRoomRequest struct {
-	Name        string                 `json:"name" validate:"required"`
-	Code        string                 `json:"code" validate:"required"`
-	Capacity    string                 `json:"capacity" validate:"required"`
-	Description string                 `json:"description" validate:"required"`
-	Image       []multipart.FileHeader `json:"image"`
+	Name             string                 `json:"name" validate:"required"`
+	Code             string                 `json:"code" validate:"required"`
+	Capacity         int                    `json:"capacity" validate:"required,min=1"`
+	Description      string                 `json:"description" validate:"required"`
+	Image            []multipart.FileHeader `json:"image"`
+	RequiresApproval bool                   `json:"requiresApproval"`
 }
 
 type RoomResponse struct {
-	UUID        uuid.UUID `json:"uuid"`
-	Code        string    `json:"code"`
-	Name        string    `json:"name"`
-	Capacity    string    `json:"capacity"`
-	Description string    `json:"description"`
-	Image       []string  `json:"image"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	UUID             uuid.UUID         `json:"uuid"`
+	Library          string            `json:"library"`
+	Code             string            `json:"code"`
+	Name             string            `json:"name"`
+	Capacity         int               `json:"capacity"`
+	Description      string            `json:"description"`
+	RequiresApproval bool              `json:"requiresApproval"`
+	Image            []string          `json:"image"`
+	Amenities        []AmenityResponse `json:"amenities"`
+	CreatedAt        time.Time         `json:"createdAt"`
+	UpdatedAt        time.Time         `json:"updatedAt"`
 }
 
 type RoomDetailResponse struct {
-	Code        string    `json:"code"`
-	Name        string    `json:"name"`
-	Capacity    string    `json:"capacity"`
-	Description string    `json:"description"`
-	Image       []string  `json:"image"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	Code             string    `json:"code"`
+	Name             string    `json:"name"`
+	Capacity         int       `json:"capacity"`
+	Description      string    `json:"description"`
+	RequiresApproval bool      `json:"requiresApproval"`
+	Image            []string  `json:"image"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
 }
 
 type RoomRequestParam struct {
 	Page       int     `json:"page" validates:"required"`
 	Limit      int     `json:"limit" validates:"required"`
-	SortColumn *string `json:"sortColumn"`
-	SortOrder  *string `json:"sortOrder"`
+	SortColumn *string `json:"sortColumn" validate:"omitempty,oneof=created_at updated_at code name capacity"`
+	SortOrder  *string `json:"sortOrder" validate:"omitempty,oneof=asc desc"`
 	SetOrder   *string `json:"setOrder"`
+	RoomFilterParam
+}
+
+// RoomFilterParam narrows room listings. Amenities is a comma separated list
+// of amenity UUIDs or names, all of which a room must have.
+type RoomFilterParam struct {
+	MinCapacity *int   `json:"minCapacity" form:"minCapacity" validate:"omitempty,min=0"`
+	MaxCapacity *int   `json:"maxCapacity" form:"maxCapacity" validate:"omitempty,min=0"`
+	Amenities   string `json:"amenities" form:"amenities"`
+}
+
+type RoomCheckInTokenResponse struct {
+	RoomID   uuid.UUID `json:"roomID"`
+	RoomName string    `json:"roomName"`
+	Token    string    `json:"token"`
+}
+
+// RoomImportRequest uploads a CSV or XLSX file of rooms. Rows are matched to
+// existing rooms of the library by code. Library only applies to callers
+// without a library of their own, who must give one.
+type RoomImportRequest struct {
+	File    *multipart.FileHeader `form:"file" validate:"required"`
+	DryRun  bool                  `form:"dryRun"`
+	Library string                `form:"library"`
+}
+
+// RoomImportRow is one row of an import file, validated like a room request.
+type RoomImportRow struct {
+	Code             string   `json:"code" validate:"required,max=15"`
+	Name             string   `json:"name" validate:"required,max=100"`
+	Capacity         int      `json:"capacity" validate:"required,min=1"`
+	Description      string   `json:"description" validate:"required,max=100"`
+	RequiresApproval bool     `json:"requiresApproval"`
+	Images           []string `json:"images" validate:"dive,url"`
+}
+
+type RoomImportRowResult struct {
+	Row    int      `json:"row"`
+	Code   string   `json:"code"`
+	Action string   `json:"action"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// RoomImportResponse reports what the import did, or would do on a dry run.
+// Nothing is written unless every row is valid.
+type RoomImportResponse struct {
+	DryRun  bool                  `json:"dryRun"`
+	Applied bool                  `json:"applied"`
+	Created int                   `json:"created"`
+	Updated int                   `json:"updated"`
+	Invalid int                   `json:"invalid"`
+	Rows    []RoomImportRowResult `json:"rows"`
+}
+
+type RoomExportParam struct {
+	Format string `json:"format" form:"format" validate:"omitempty,oneof=csv xlsx"`
+}
+
+type RoomExportFile struct {
+	Name        string
+	ContentType string
+	Data        []byte
 }
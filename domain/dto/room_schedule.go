@@ -17,19 +17,40 @@ type GenerateRoomScheduleForOneMostRequest struct {
 	RoomID string `json:"RoomID" validate:"required"`
 }
 
+type GenerateRoomScheduleRequest struct {
+	RoomID    string   `json:"roomID" validate:"required"`
+	StartDate string   `json:"startDate" validate:"required,datetime=2006-01-02"`
+	EndDate   string   `json:"endDate" validate:"required,datetime=2006-01-02"`
+	Weekdays  []int    `json:"weekdays" validate:"dive,min=0,max=6"`
+	TimeIDs   []string `json:"timeIDs"`
+	Mode      string   `json:"mode" validate:"omitempty,oneof=skip fail"`
+}
+
+type GeneratedRoomScheduleResponse struct {
+	Date      string `json:"date"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type GenerateRoomScheduleResponse struct {
+	Created []GeneratedRoomScheduleResponse `json:"created"`
+	Skipped []GeneratedRoomScheduleResponse `json:"skipped"`
+}
+
 type UpdateRoomScheduleRequest struct {
 	Date   string `json:"date" validate:"required"`
 	TimeID string `json:"timeID" validate:"required"`
 }
 
 type UpdateStatusRoomScheduleRequest struct {
-	RoomScheduleIDs []string `json:"roomScheduleIDs" validate:"required"`
+	Status constants.RoomScheduleStatusName `json:"status" validate:"required"`
 }
 
 type RoomScheduleResponse struct {
 	UUID        uuid.UUID                        `json:"uuid"`
 	RoomName    string                           `json:"roomName"`
-	Capacity    string                           `json:"capacity"`
+	Capacity    int                              `json:"capacity"`
 	Description string                           `json:"description"`
 	Date        string                           `json:"date"`
 	Status      constants.RoomScheduleStatusName `json:"status"`
@@ -41,20 +62,94 @@ type RoomScheduleResponse struct {
 type RoomScheduleForBookingResponse struct {
 	UUID        uuid.UUID                        `json:"uuid"`
 	Date        string                           `json:"date"`
-	Capacity    string                           `json:"capacity"`
+	Capacity    int                              `json:"capacity"`
 	Description string                           `json:"description"`
 	Status      constants.RoomScheduleStatusName `json:"status"`
 	Time        string                           `json:"time"`
+	EndTime     string                           `json:"endTime"`
 }
 
 type RoomScheduleRequestParam struct {
 	Page       int     `json:"page" validates:"required"`
 	Limit      int     `json:"limit" validates:"required"`
-	SortColumn *string `json:"sortColumn"`
-	SortOrder  *string `json:"sortOrder"`
+	SortColumn *string `json:"sortColumn" validate:"omitempty,oneof=created_at updated_at date status"`
+	SortOrder  *string `json:"sortOrder" validate:"omitempty,oneof=asc desc"`
 	SetOrder   *string `json:"setOrder"`
 }
 
 type RoomScheduleByRoomIDAndDateRequestParam struct {
 	Date string `json:"date" validate:"required"`
 }
+
+// AvailabilitySearchParam looks for free slots between StartDate and EndDate,
+// both inclusive, that fit within the optional StartTime and EndTime window.
+type AvailabilitySearchParam struct {
+	StartDate string `json:"startDate" form:"startDate" validate:"required,datetime=2006-01-02"`
+	EndDate   string `json:"endDate" form:"endDate" validate:"omitempty,datetime=2006-01-02"`
+	StartTime string `json:"startTime" form:"startTime" validate:"omitempty,datetime=15:04"`
+	EndTime   string `json:"endTime" form:"endTime" validate:"omitempty,datetime=15:04"`
+	Capacity  int    `json:"capacity" form:"capacity" validate:"omitempty,min=1"`
+	Amenities string `json:"amenities" form:"amenities"`
+}
+
+type AvailableSlotResponse struct {
+	UUID      uuid.UUID `json:"uuid"`
+	Date      string    `json:"date"`
+	StartTime string    `json:"startTime"`
+	EndTime   string    `json:"endTime"`
+}
+
+type AvailableRoomResponse struct {
+	UUID     uuid.UUID               `json:"uuid"`
+	Library  string                  `json:"library"`
+	Code     string                  `json:"code"`
+	Name     string                  `json:"name"`
+	Capacity int                     `json:"capacity"`
+	Slots    []AvailableSlotResponse `json:"slots"`
+}
+
+// CalendarRequestParam asks for the week, starting on Monday, or the month
+// that contains Date.
+type CalendarRequestParam struct {
+	RoomID string `json:"roomID" form:"roomID" validate:"required"`
+	Date   string `json:"date" form:"date" validate:"required,datetime=2006-01-02"`
+	View   string `json:"view" form:"view" validate:"omitempty,oneof=week month"`
+}
+
+type CalendarSlotResponse struct {
+	TimeID     uuid.UUID                        `json:"timeID"`
+	StartTime  string                           `json:"startTime"`
+	EndTime    string                           `json:"endTime"`
+	ScheduleID *uuid.UUID                       `json:"scheduleID"`
+	Status     constants.RoomScheduleStatusName `json:"status"`
+}
+
+type CalendarDayResponse struct {
+	Date         string                 `json:"date"`
+	Weekday      string                 `json:"weekday"`
+	ClosedReason string                 `json:"closedReason,omitempty"`
+	Slots        []CalendarSlotResponse `json:"slots"`
+}
+
+type CalendarResponse struct {
+	RoomID    uuid.UUID             `json:"roomID"`
+	RoomName  string                `json:"roomName"`
+	View      string                `json:"view"`
+	StartDate string                `json:"startDate"`
+	EndDate   string                `json:"endDate"`
+	Days      []CalendarDayResponse `json:"days"`
+}
+
+type HoldRoomScheduleRequest struct {
+	RoomScheduleIDs []string `json:"roomScheduleIDs" validate:"required,min=1,dive,required"`
+}
+
+type HeldRoomScheduleResponse struct {
+	UUID      uuid.UUID  `json:"uuid"`
+	RoomID    uuid.UUID  `json:"roomID"`
+	RoomName  string     `json:"roomName"`
+	Date      string     `json:"date"`
+	StartTime string     `json:"startTime"`
+	EndTime   string     `json:"endTime"`
+	HeldUntil *time.Time `json:"heldUntil"`
+}
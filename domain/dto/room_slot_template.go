@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+type RoomSlotTemplateItemRequest struct {
+	Weekday *int   `json:"weekday" validate:"omitempty,min=0,max=6"`
+	TimeID  string `json:"timeID" validate:"required"`
+}
+
+type RoomSlotTemplateRequest struct {
+	Templates []RoomSlotTemplateItemRequest `json:"templates" validate:"dive"`
+}
+
+type RoomSlotTemplateResponse struct {
+	UUID      uuid.UUID `json:"uuid"`
+	Weekday   *int      `json:"weekday"`
+	TimeID    uuid.UUID `json:"timeID"`
+	StartTime string    `json:"startTime"`
+	EndTime   string    `json:"endTime"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"room-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WaitlistRequest struct {
+	RoomScheduleID string `json:"roomScheduleID" validate:"required"`
+	Purpose        string `json:"purpose" validate:"required,max=255"`
+	Attendees      int    `json:"attendees" validate:"required,min=1"`
+}
+
+type WaitlistResponse struct {
+	UUID           uuid.UUID                    `json:"uuid"`
+	RoomScheduleID uuid.UUID                    `json:"roomScheduleID"`
+	RoomID         uuid.UUID                    `json:"roomID"`
+	RoomName       string                       `json:"roomName"`
+	Date           string                       `json:"date"`
+	StartTime      string                       `json:"startTime"`
+	EndTime        string                       `json:"endTime"`
+	UserID         uuid.UUID                    `json:"userID"`
+	UserName       string                       `json:"userName"`
+	Purpose        string                       `json:"purpose"`
+	Attendees      int                          `json:"attendees"`
+	Position       int                          `json:"position,omitempty"`
+	Status         constants.WaitlistStatusName `json:"status"`
+	OfferedAt      *time.Time                   `json:"offeredAt"`
+	OfferExpiresAt *time.Time                   `json:"offerExpiresAt"`
+	CreatedAt      *time.Time                   `json:"createdAt"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Amenity struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	UUID        uuid.UUID `gorm:"type:uuid;not null"`
+	Name        string    `gorm:"type:varchar(50);not null;uniqueIndex"`
+	Description string    `gorm:"type:varchar(100)"`
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+}
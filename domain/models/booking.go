@@ -0,0 +1,50 @@
+package models
+
+import (
+	"room-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Booking struct {
+	ID        uint                    `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID               `gorm:"type:uuid;not null"`
+	UserID    uuid.UUID               `gorm:"type:uuid;not null;index"`
+	UserName  string                  `gorm:"type:varchar(100);not null"`
+	RoomID    uint                    `gorm:"type:int;not null"`
+	Purpose   string                  `gorm:"type:varchar(255);not null"`
+	Attendees int                     `gorm:"type:int;not null"`
+	Status    constants.BookingStatus `gorm:"type:int;not null"`
+	SeriesID  *uint                   `gorm:"type:int;index"`
+
+	ReviewedAt *time.Time
+	ReviewedBy *uuid.UUID `gorm:"type:uuid"`
+	ReviewNote string     `gorm:"type:varchar(255)"`
+
+	CheckedInAt *time.Time
+	CheckedInBy *uuid.UUID `gorm:"type:uuid"`
+
+	CancelledAt        *time.Time
+	CancelledBy        *uuid.UUID `gorm:"type:uuid"`
+	CancellationReason string     `gorm:"type:varchar(255)"`
+	CreatedAt          *time.Time
+	UpdatedAt          *time.Time
+
+	Room             Room              `gorm:"foreignKey:room_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	BookingSchedules []BookingSchedule `gorm:"foreignKey:booking_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// BookingSchedule links a booking to one of its slots. A slot can only be
+// held by one unreleased link at a time, which the partial unique index
+// enforces even if two transactions slip past the row lock.
+type BookingSchedule struct {
+	ID             uint `gorm:"primaryKey;autoIncrement"`
+	BookingID      uint `gorm:"type:int;not null"`
+	RoomScheduleID uint `gorm:"type:int;not null;uniqueIndex:idx_booking_schedules_active,where:released_at IS NULL"`
+	ReleasedAt     *time.Time
+	CreatedAt      *time.Time
+	UpdatedAt      *time.Time
+
+	RoomSchedule RoomSchedule `gorm:"foreignKey:room_schedule_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BookingQuota limits how much a role, or a single user when UserID is set,
+// may book. A zero limit means unlimited. MaxNoShows counts the no-shows
+// within the configured no-show window.
+type BookingQuota struct {
+	ID                uint       `gorm:"primaryKey;autoIncrement"`
+	UUID              uuid.UUID  `gorm:"type:uuid;not null"`
+	Role              string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_booking_quotas_role,where:user_id IS NULL"`
+	UserID            *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_booking_quotas_user"`
+	MaxHoursPerDay    int        `gorm:"type:int;not null;default:0"`
+	MaxActiveBookings int        `gorm:"type:int;not null;default:0"`
+	MaxDaysInAdvance  int        `gorm:"type:int;not null;default:0"`
+	MaxNoShows        int        `gorm:"type:int;not null;default:0"`
+	CreatedAt         *time.Time
+	UpdatedAt         *time.Time
+}
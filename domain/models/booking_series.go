@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BookingSeries books a room at the same time on every date produced by a
+// recurrence rule. Each occurrence is a regular Booking pointing back at the
+// series, so it can be checked in or cancelled on its own.
+type BookingSeries struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	UUID        uuid.UUID `gorm:"type:uuid;not null"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	UserName    string    `gorm:"type:varchar(100);not null"`
+	RoomID      uint      `gorm:"type:int;not null"`
+	RRule       string    `gorm:"type:varchar(255);not null"`
+	StartDate   time.Time `gorm:"type:date;not null"`
+	EndDate     time.Time `gorm:"type:date;not null"`
+	StartTime   string    `gorm:"type:time;not null"`
+	EndTime     string    `gorm:"type:time;not null"`
+	Purpose     string    `gorm:"type:varchar(255);not null"`
+	Attendees   int       `gorm:"type:int;not null"`
+	CancelledAt *time.Time
+	CancelledBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+
+	Room     Room      `gorm:"foreignKey:room_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Bookings []Booking `gorm:"foreignKey:series_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarFeed lets calendar apps, which cannot authenticate, read the
+// bookings of a room or of a user. Its URL is signed over UUID and Secret,
+// and stops working once RevokedAt is set.
+type CalendarFeed struct {
+	ID        uint       `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	Secret    string     `gorm:"type:varchar(64);not null"`
+	Scope     string     `gorm:"type:varchar(10);not null"`
+	Library   string     `gorm:"type:varchar(50);index"`
+	RoomID    *uint      `gorm:"type:int"`
+	UserID    *uuid.UUID `gorm:"type:uuid"`
+	CreatedBy uuid.UUID  `gorm:"type:uuid;not null;index"`
+	RevokedAt *time.Time
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+
+	Room *Room `gorm:"foreignKey:room_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Closure marks the days a library, or a single room when RoomID is set, is
+// closed, such as national holidays and campus events.
+type Closure struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID `gorm:"type:uuid;not null"`
+	Library   string    `gorm:"type:varchar(50);index"`
+	RoomID    *uint     `gorm:"type:int"`
+	StartDate time.Time `gorm:"type:date;not null"`
+	EndDate   time.Time `gorm:"type:date;not null"`
+	Reason    string    `gorm:"type:varchar(255);not null"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+
+	Room *Room `gorm:"foreignKey:room_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// Covers reports whether the closure applies to the room on the given date.
+func (c *Closure) Covers(roomID uint, date time.Time) bool {
+	if c.RoomID != nil && *c.RoomID != roomID {
+		return false
+	}
+	return !date.Before(c.StartDate) && !date.After(c.EndDate)
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"room-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Strike records a booking outcome held against a user, such as a no-show or
+// a late cancellation. Cleared strikes no longer count.
+type Strike struct {
+	ID        uint                   `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID              `gorm:"type:uuid;not null"`
+	UserID    uuid.UUID              `gorm:"type:uuid;not null;index"`
+	UserName  string                 `gorm:"type:varchar(100);not null"`
+	Library   string                 `gorm:"type:varchar(50);index"`
+	BookingID *uint                  `gorm:"type:int"`
+	Reason    constants.StrikeReason `gorm:"type:varchar(30);not null"`
+	ClearedAt *time.Time
+	ClearedBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+
+	Booking *Booking `gorm:"foreignKey:booking_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+}
+
+// Suspension keeps a user from booking until EndsAt, unless it is lifted
+// earlier.
+type Suspension struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID `gorm:"type:uuid;not null"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	UserName  string    `gorm:"type:varchar(100);not null"`
+	Library   string    `gorm:"type:varchar(50);index"`
+	Reason    string    `gorm:"type:varchar(255);not null"`
+	EndsAt    time.Time `gorm:"not null"`
+	LiftedAt  *time.Time
+	LiftedBy  *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
+
+// IsActive reports whether the suspension still applies at the given time.
+func (s *Suspension) IsActive(at time.Time) bool {
+	return s.LiftedAt == nil && at.Before(s.EndsAt)
+}
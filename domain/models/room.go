@@ -9,16 +9,19 @@ import (
 )
 
 type Room struct {
-	ID   uint      `gorm:"primaryKey;autoIncrement"`
-	UUID uuid.UUID `gorm:"type:uuid;not null"`
-	// LibraryID     uint      `gorm:"type:int;not null"`
-	Image         pq.StringArray `gorm:"type:text[];not null"`
-	Code          string         `gorm:"type:varchar(15);not null"`
-	Name          string         `gorm:"type:varchar(100);not null"`
-	Capacity      string         `gorm:"type:varchar(15);not null"`
-	Description   string         `gorm:"type:varchar(100);not null"`
-	CreatedAt     *time.Time
-	UpdatedAt     *time.Time
-	DeletedAt     *gorm.DeletedAt
-	RoomSchedules []RoomSchedule `gorm:"foreignKey:room_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ID               uint           `gorm:"primaryKey;autoIncrement"`
+	UUID             uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex"`
+	Library          string         `gorm:"type:varchar(50);index;uniqueIndex:idx_rooms_library_code,where:deleted_at IS NULL"`
+	Image            pq.StringArray `gorm:"type:text[];not null"`
+	Code             string         `gorm:"type:varchar(15);not null;uniqueIndex:idx_rooms_library_code,where:deleted_at IS NULL"`
+	Name             string         `gorm:"type:varchar(100);not null"`
+	Capacity         int            `gorm:"type:int;not null;default:0"`
+	Description      string         `gorm:"type:varchar(100);not null"`
+	RequiresApproval bool           `gorm:"not null;default:false"`
+	CheckInToken     string         `gorm:"type:varchar(64);not null;default:''"`
+	CreatedAt        *time.Time
+	UpdatedAt        *time.Time
+	DeletedAt        *gorm.DeletedAt
+	RoomSchedules    []RoomSchedule `gorm:"foreignKey:room_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Amenities        []Amenity      `gorm:"many2many:room_amenities;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
@@ -2,6 +2,7 @@ package models
 
 import (
 	"room-service/constants"
+	errRoomSchedule "room-service/constants/error/roomSchedule"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,10 +11,12 @@ import (
 type RoomSchedule struct {
 	ID        uint                         `gorm:"primaryKey;autoIncrement"`
 	UUID      uuid.UUID                    `gorm:"type:uuid;not null"`
-	RoomID    uint                         `gorm:"type:int;not null"`
-	TimeID    uint                         `gorm:"type:int;not null"`
-	Date      time.Time                    `gorm:"type:date;not null"`
+	RoomID    uint                         `gorm:"type:int;not null;uniqueIndex:idx_room_schedules_slot"`
+	TimeID    uint                         `gorm:"type:int;not null;uniqueIndex:idx_room_schedules_slot"`
+	Date      time.Time                    `gorm:"type:date;not null;uniqueIndex:idx_room_schedules_slot"`
 	Status    constants.RoomScheduleStatus `gorm:"type:int;not null"`
+	HeldBy    *uuid.UUID                   `gorm:"type:uuid"`
+	HeldUntil *time.Time
 	CreatedAt *time.Time
 	UpdatedAt *time.Time
 	DeletedAt *time.Time
@@ -21,3 +24,24 @@ type RoomSchedule struct {
 	Room Room `gorm:"foreignKey:room_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	Time Time `gorm:"foreignKey:time_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
+
+// IsHeldFor reports whether the slot is held for the user at the given time.
+func (r *RoomSchedule) IsHeldFor(userID uuid.UUID, at time.Time) bool {
+	return r.Status == constants.Held &&
+		r.HeldBy != nil && *r.HeldBy == userID &&
+		r.HeldUntil != nil && at.Before(*r.HeldUntil)
+}
+
+// TransitionTo moves the schedule to the given status, rejecting any change
+// the status lifecycle does not allow.
+func (r *RoomSchedule) TransitionTo(status constants.RoomScheduleStatus) error {
+	if !r.Status.CanTransitionTo(status) {
+		return &errRoomSchedule.InvalidStatusTransitionError{
+			From: r.Status.GetStatusString(),
+			To:   status.GetStatusString(),
+		}
+	}
+
+	r.Status = status
+	return nil
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoomSlotTemplate opens a time slot for a room, on every day when Weekday is
+// nil or only on that weekday (0 is Sunday).
+type RoomSlotTemplate struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID `gorm:"type:uuid;not null"`
+	RoomID    uint      `gorm:"type:int;not null;index"`
+	Weekday   *int      `gorm:"type:int"`
+	TimeID    uint      `gorm:"type:int;not null"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+
+	Room Room `gorm:"foreignKey:room_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Time Time `gorm:"foreignKey:time_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// RoomSlotTemplates is the full template of a single room.
+type RoomSlotTemplates []RoomSlotTemplate
+
+// Allows reports whether the time slot is open on the weekday. A room
+// without any template is open for every time slot of its library.
+func (r RoomSlotTemplates) Allows(weekday time.Weekday, timeID uint) bool {
+	if len(r) == 0 {
+		return true
+	}
+
+	for _, item := range r {
+		if item.TimeID == timeID && (item.Weekday == nil || *item.Weekday == int(weekday)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -9,8 +9,9 @@ import (
 type Time struct {
 	ID        uint      `gorm:"primaryKey;autoIncrement"`
 	UUID      uuid.UUID `gorm:"type:uuid;not null"`
-	StartTime string    `gorm:"type:time with time zone;not null"`
-	EndTime   string    `gorm:"type:time with time zone;not null"`
+	Library   string    `gorm:"type:varchar(50);index"`
+	StartTime string    `gorm:"type:time;not null"`
+	EndTime   string    `gorm:"type:time;not null"`
 	CreatedAt *time.Time
 	UpdatedAt *time.Time
 }
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"room-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WaitlistEntry queues a user for a taken slot. When the slot is given back
+// it is held for the first waiting entry until OfferExpiresAt; a user can
+// only wait once per slot.
+type WaitlistEntry struct {
+	ID             uint                     `gorm:"primaryKey;autoIncrement"`
+	UUID           uuid.UUID                `gorm:"type:uuid;not null"`
+	RoomScheduleID uint                     `gorm:"type:int;not null;index;uniqueIndex:idx_waitlist_entries_active,where:status IN (100, 200)"`
+	UserID         uuid.UUID                `gorm:"type:uuid;not null;index;uniqueIndex:idx_waitlist_entries_active,where:status IN (100, 200)"`
+	UserName       string                   `gorm:"type:varchar(100);not null"`
+	UserRole       string                   `gorm:"type:varchar(20);not null"`
+	Purpose        string                   `gorm:"type:varchar(255);not null"`
+	Attendees      int                      `gorm:"type:int;not null"`
+	Status         constants.WaitlistStatus `gorm:"type:int;not null"`
+	OfferedAt      *time.Time
+	OfferExpiresAt *time.Time
+	CreatedAt      *time.Time
+	UpdatedAt      *time.Time
+
+	RoomSchedule RoomSchedule `gorm:"foreignKey:room_schedule_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
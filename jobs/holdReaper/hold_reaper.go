@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"context"
+	"room-service/config"
+	"room-service/services"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LockKey identifies the reaper's advisory lock so only one replica releases
+// holds at a time.
+const LockKey int64 = 7_001_003
+
+type HoldReaperJob struct {
+	service services.IServiceRegistry
+}
+
+type IHoldReaperJob interface {
+	Interval() time.Duration
+	Run(context.Context) error
+}
+
+func NewHoldReaperJob(service services.IServiceRegistry) IHoldReaperJob {
+	return &HoldReaperJob{service: service}
+}
+
+// Interval is how often expired holds are released.
+func (h *HoldReaperJob) Interval() time.Duration {
+	return time.Duration(config.Config.Hold.IntervalSecond) * time.Second
+}
+
+// Run releases expired holds.
+func (h *HoldReaperJob) Run(ctx context.Context) error {
+	released, err := h.service.GetRoomSchedule().ReleaseExpiredHolds(ctx)
+	if released > 0 {
+		logrus.Infof("hold reaper: released %d holds", released)
+	}
+	return err
+}
@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"context"
+	"room-service/config"
+	"room-service/services"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LockKey identifies the job's advisory lock so only one replica marks
+// no-shows at a time.
+const LockKey int64 = 7_001_004
+
+type NoShowJob struct {
+	service services.IServiceRegistry
+}
+
+type INoShowJob interface {
+	Interval() time.Duration
+	Run(context.Context) error
+}
+
+func NewNoShowJob(service services.IServiceRegistry) INoShowJob {
+	return &NoShowJob{service: service}
+}
+
+// Interval is how often missed check-ins are looked for.
+func (n *NoShowJob) Interval() time.Duration {
+	return time.Duration(config.Config.CheckIn.IntervalMinute) * time.Minute
+}
+
+// Run marks missed check-ins as no-shows.
+func (n *NoShowJob) Run(ctx context.Context) error {
+	marked, err := n.service.GetBooking().MarkNoShows(ctx)
+	if marked > 0 {
+		logrus.Infof("no-show: marked %d bookings", marked)
+	}
+	return err
+}
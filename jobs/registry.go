@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"context"
+	holdReaperJob "room-service/jobs/holdReaper"
+	noShowJob "room-service/jobs/noShow"
+	scheduleGeneratorJob "room-service/jobs/scheduleGenerator"
+	waitlistOfferJob "room-service/jobs/waitlistOffer"
+	"room-service/repositories"
+	"room-service/services"
+)
+
+type Registry struct {
+	repository repositories.IRepositoryRegistry
+	service    services.IServiceRegistry
+}
+
+type IJobRegistry interface {
+	Start(context.Context)
+}
+
+func NewJobRegistry(repository repositories.IRepositoryRegistry, service services.IServiceRegistry) IJobRegistry {
+	return &Registry{repository: repository, service: service}
+}
+
+func (r *Registry) holdReaper() holdReaperJob.IHoldReaperJob {
+	return holdReaperJob.NewHoldReaperJob(r.service)
+}
+
+func (r *Registry) noShow() noShowJob.INoShowJob {
+	return noShowJob.NewNoShowJob(r.service)
+}
+
+func (r *Registry) scheduleGenerator() scheduleGeneratorJob.IScheduleGeneratorJob {
+	return scheduleGeneratorJob.NewScheduleGeneratorJob(r.service)
+}
+
+func (r *Registry) waitlistOffer() waitlistOfferJob.IWaitlistOfferJob {
+	return waitlistOfferJob.NewWaitlistOfferJob(r.service)
+}
+
+// Start runs every background job until ctx is done.
+func (r *Registry) Start(ctx context.Context) {
+	scheduleGenerator := r.scheduleGenerator()
+	r.run(ctx, "schedule generator", scheduleGenerator.Interval(), scheduleGeneratorJob.LockKey, scheduleGenerator.Run)
+
+	waitlistOffer := r.waitlistOffer()
+	r.run(ctx, "waitlist offer", waitlistOffer.Interval(), waitlistOfferJob.LockKey, waitlistOffer.Run)
+
+	holdReaper := r.holdReaper()
+	r.run(ctx, "hold reaper", holdReaper.Interval(), holdReaperJob.LockKey, holdReaper.Run)
+
+	noShow := r.noShow()
+	r.run(ctx, "no-show", noShow.Interval(), noShowJob.LockKey, noShow.Run)
+}
@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"context"
+	"room-service/common/util"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// run calls fn once straight away and then on every interval until ctx is
+// done. Each call holds the advisory lock identified by lockKey, so only one
+// replica runs the job at a time. A job without a positive interval does not
+// run at all.
+func (r *Registry) run(ctx context.Context, name string, interval time.Duration, lockKey int64, fn func(context.Context) error) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			r.runOnce(ctx, name, lockKey, fn)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (r *Registry) runOnce(ctx context.Context, name string, lockKey int64, fn func(context.Context) error) {
+	locked, err := util.WithAdvisoryLock(ctx, r.repository.GetTx(), lockKey, fn)
+	if err != nil {
+		logrus.Errorf("%s: %v", name, err)
+		return
+	}
+
+	if !locked {
+		logrus.Debugf("%s: skipped, another replica holds the lock", name)
+	}
+}
@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"room-service/config"
+	"room-service/services"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LockKey identifies the generator's advisory lock so only one replica
+// generates at a time.
+const LockKey int64 = 7_001_001
+
+type ScheduleGeneratorJob struct {
+	service services.IServiceRegistry
+}
+
+type IScheduleGeneratorJob interface {
+	Interval() time.Duration
+	Run(context.Context) error
+}
+
+func NewScheduleGeneratorJob(service services.IServiceRegistry) IScheduleGeneratorJob {
+	return &ScheduleGeneratorJob{service: service}
+}
+
+// Interval is how often the rolling window is topped up. It is zero while
+// the generator is disabled or has no days to fill.
+func (s *ScheduleGeneratorJob) Interval() time.Duration {
+	cfg := config.Config.ScheduleGenerator
+	if !cfg.Enabled || cfg.DaysAhead <= 0 {
+		return 0
+	}
+
+	return time.Duration(cfg.IntervalMinute) * time.Minute
+}
+
+// Run keeps every room populated with slots for the configured number of
+// days ahead.
+func (s *ScheduleGeneratorJob) Run(ctx context.Context) error {
+	created, err := s.service.GetRoomSchedule().GenerateRollingWindow(ctx, config.Config.ScheduleGenerator.DaysAhead)
+	if created > 0 {
+		logrus.Infof("schedule generator: created %d schedules", created)
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"context"
+	"room-service/config"
+	"room-service/services"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LockKey identifies the job's advisory lock so only one replica passes
+// offers on at a time.
+const LockKey int64 = 7_001_002
+
+type WaitlistOfferJob struct {
+	service services.IServiceRegistry
+}
+
+type IWaitlistOfferJob interface {
+	Interval() time.Duration
+	Run(context.Context) error
+}
+
+func NewWaitlistOfferJob(service services.IServiceRegistry) IWaitlistOfferJob {
+	return &WaitlistOfferJob{service: service}
+}
+
+// Interval is how often lapsed offers are expired.
+func (w *WaitlistOfferJob) Interval() time.Duration {
+	return time.Duration(config.Config.Waitlist.IntervalMinute) * time.Minute
+}
+
+// Run expires waitlist offers nobody accepted in time, passing their slots
+// on to the next user in line.
+func (w *WaitlistOfferJob) Run(ctx context.Context) error {
+	expired, err := w.service.GetWaitlist().ExpireOffers(ctx)
+	if expired > 0 {
+		logrus.Infof("waitlist offer: expired %d offers", expired)
+	}
+	return err
+}
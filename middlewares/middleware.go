@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"room-service/config"
 	"room-service/constants"
 	errConstant "room-service/constants/error"
+	"strings"
 
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
@@ -48,13 +50,13 @@ func RateLimiter(let *limiter.Limiter) gin.HandlerFunc {
 	}
 }
 
-// func extractBearerToken(token string) string {
-// 	arrayToken := strings.Split(token, " ")
-// 	if len(arrayToken) == 2 {
-// 		return arrayToken[1]
-// 	}
-// 	return ""
-// }
+func extractBearerToken(token string) string {
+	arrayToken := strings.Split(token, " ")
+	if len(arrayToken) == 2 {
+		return arrayToken[1]
+	}
+	return ""
+}
 
 func responseUnauthorized(c *gin.Context, message string) {
 	c.JSON(http.StatusUnauthorized, response.Response{
@@ -85,6 +87,9 @@ func CheckRole(roles []string, client clients.IClientRegistry) gin.HandlerFunc {
 			responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
 			return
 		}
+
+		c.Set(constants.User, user)
+		c.Set(constants.Library, user.Library)
 		c.Next()
 	}
 }
@@ -121,6 +126,8 @@ func Authenticate() gin.HandlerFunc {
 			return
 		}
 
+		tokenString := extractBearerToken(token)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), constants.Token, tokenString))
 		c.Next()
 	}
 }
@@ -136,3 +143,15 @@ func AuthenticateWithoutToken() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// SetLibrary scopes public endpoints, which have no caller to take the
+// library from, to the library given in the query string.
+func SetLibrary() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		library := c.Query(constants.Library)
+		if library != "" {
+			c.Set(constants.Library, library)
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,90 @@
+package migrations
+
+import (
+	"room-service/config"
+	"room-service/domain/models"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// backfillLibrary gives rooms and time slots created before they were scoped
+// to a library a library of their own, so they do not disappear for scoped
+// callers. A time slot takes the library of a room scheduled on it; anything
+// else gets the configured default library. Only NULL libraries are touched,
+// as rows created since always have one.
+func backfillLibrary(db *gorm.DB) error {
+	library := config.Config.Migration.DefaultLibrary
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []any{&models.Room{}, &models.Time{}} {
+			if !tx.Migrator().HasTable(model) || tx.Migrator().HasColumn(model, "Library") {
+				continue
+			}
+
+			err := tx.Migrator().AddColumn(model, "Library")
+			if err != nil {
+				return err
+			}
+		}
+
+		if tx.Migrator().HasTable(&models.Room{}) {
+			var codes []string
+			err := tx.Raw("SELECT code FROM rooms WHERE library IS NULL ORDER BY code").Scan(&codes).Error
+			if err != nil {
+				return err
+			}
+
+			if len(codes) > 0 {
+				if library == "" {
+					logrus.Warnf("rooms without a library stay hidden until migration.defaultLibrary is set: %s", strings.Join(codes, ", "))
+				} else {
+					err = tx.Exec("UPDATE rooms SET library = ? WHERE library IS NULL", library).Error
+					if err != nil {
+						return err
+					}
+					logrus.Infof("rooms were assigned to %s: %s", library, strings.Join(codes, ", "))
+				}
+			}
+		}
+
+		if !tx.Migrator().HasTable(&models.Time{}) {
+			return nil
+		}
+
+		if tx.Migrator().HasTable(&models.RoomSchedule{}) && tx.Migrator().HasTable(&models.Room{}) {
+			result := tx.Exec(`UPDATE times SET library = (
+				SELECT rooms.library FROM room_schedules
+				JOIN rooms ON rooms.id = room_schedules.room_id
+				WHERE room_schedules.time_id = times.id AND rooms.library IS NOT NULL
+				LIMIT 1
+			) WHERE library IS NULL`)
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+
+		var times []string
+		err := tx.Raw("SELECT start_time || '-' || end_time FROM times WHERE library IS NULL ORDER BY start_time").Scan(&times).Error
+		if err != nil {
+			return err
+		}
+
+		if len(times) == 0 {
+			return nil
+		}
+
+		if library == "" {
+			logrus.Warnf("time slots without a library stay hidden until migration.defaultLibrary is set: %s", strings.Join(times, ", "))
+			return nil
+		}
+
+		err = tx.Exec("UPDATE times SET library = ? WHERE library IS NULL", library).Error
+		if err != nil {
+			return err
+		}
+		logrus.Infof("unused time slots were assigned to %s: %s", library, strings.Join(times, ", "))
+
+		return nil
+	})
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// Run applies the schema and data changes AutoMigrate cannot express. Each
+// migration checks the current schema first, so running them again on every
+// start is a no-op.
+func Run(db *gorm.DB) error {
+	migrations := []func(*gorm.DB) error{
+		convertRoomCapacityToInt,
+		backfillLibrary,
+		convertTimeColumnsToTime,
+		dedupeRoomCodes,
+		dedupeRoomSchedules,
+	}
+
+	for _, migrate := range migrations {
+		err := migrate(db)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"room-service/domain/models"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// convertRoomCapacityToInt turns the free-form capacity column into an
+// integer, keeping the first number found in each value, such as 12 for
+// "12 orang". Values without a number become 0.
+func convertRoomCapacityToInt(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.Room{}) {
+		return nil
+	}
+
+	columnTypes, err := db.Migrator().ColumnTypes(&models.Room{})
+	if err != nil {
+		return err
+	}
+
+	isText := false
+	for _, columnType := range columnTypes {
+		if columnType.Name() == "capacity" {
+			typeName := strings.ToLower(columnType.DatabaseTypeName())
+			isText = strings.Contains(typeName, "char") || typeName == "text"
+		}
+	}
+
+	if !isText {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`ALTER TABLE rooms ALTER COLUMN capacity TYPE int
+			USING COALESCE(substring(capacity from '[0-9]{1,9}')::int, 0)`).Error
+		if err != nil {
+			return err
+		}
+
+		var unknown []string
+		err = tx.Model(&models.Room{}).Where("capacity = 0").Pluck("code", &unknown).Error
+		if err != nil {
+			return err
+		}
+
+		if len(unknown) > 0 {
+			logrus.Warnf("rooms without a numeric capacity were set to 0: %s", strings.Join(unknown, ", "))
+		}
+
+		return nil
+	})
+}
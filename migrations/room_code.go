@@ -0,0 +1,93 @@
+package migrations
+
+import (
+	"fmt"
+	"room-service/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// roomCodeLength is the length of the rooms.code column.
+const roomCodeLength = 15
+
+// dedupeRoomCodes resolves what the unique indexes on rooms would reject, so
+// AutoMigrate can build them. Within a library the oldest room keeps its code
+// and later rooms with the same code get a numbered suffix, such as R-101-2.
+// Rooms sharing a UUID, deleted ones included, get a new one. Every change is
+// logged.
+func dedupeRoomCodes(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.Room{}) {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var rooms []struct {
+			ID      uint
+			Library string
+			Code    string
+		}
+		err := tx.Raw(`SELECT id, library, code FROM rooms
+			WHERE deleted_at IS NULL AND library IS NOT NULL
+			ORDER BY library, code, id`).Scan(&rooms).Error
+		if err != nil {
+			return err
+		}
+
+		taken := make(map[string]bool, len(rooms))
+		for _, room := range rooms {
+			taken[room.Library+"\x00"+room.Code] = true
+		}
+
+		seen := make(map[string]bool, len(rooms))
+		for _, room := range rooms {
+			key := room.Library + "\x00" + room.Code
+			if !seen[key] {
+				seen[key] = true
+				continue
+			}
+
+			code := room.Code
+			for n := 2; taken[room.Library+"\x00"+code]; n++ {
+				suffix := fmt.Sprintf("-%d", n)
+				base := room.Code
+				if len(base)+len(suffix) > roomCodeLength {
+					base = base[:roomCodeLength-len(suffix)]
+				}
+				code = base + suffix
+			}
+
+			err = tx.Exec("UPDATE rooms SET code = ? WHERE id = ?", code, room.ID).Error
+			if err != nil {
+				return err
+			}
+
+			taken[room.Library+"\x00"+code] = true
+			logrus.Warnf("room %d in %s had the duplicate code %s and was renamed to %s", room.ID, room.Library, room.Code, code)
+		}
+
+		var duplicates []struct {
+			ID   uint
+			UUID string
+		}
+		err = tx.Raw(`SELECT id, uuid FROM (
+				SELECT id, uuid, row_number() OVER (PARTITION BY uuid ORDER BY id) AS position FROM rooms
+			) AS numbered WHERE position > 1`).Scan(&duplicates).Error
+		if err != nil {
+			return err
+		}
+
+		for _, room := range duplicates {
+			newUUID := uuid.New()
+			err = tx.Exec("UPDATE rooms SET uuid = ? WHERE id = ?", newUUID, room.ID).Error
+			if err != nil {
+				return err
+			}
+
+			logrus.Warnf("room %d had the duplicate uuid %s and was given %s", room.ID, room.UUID, newUUID)
+		}
+
+		return nil
+	})
+}
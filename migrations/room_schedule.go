@@ -0,0 +1,136 @@
+package migrations
+
+import (
+	"fmt"
+	"room-service/constants"
+	"room-service/domain/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// dedupeRoomSchedules merges room schedules that share a room, time slot and
+// date, so AutoMigrate can build idx_room_schedules_slot. The schedule held by
+// an active booking is kept, or else the oldest one; the bookings and waitlist
+// entries of the others move to it before they are deleted. A user waiting on
+// more than one of them keeps only the entry on the kept schedule. Slots
+// actively booked more than once cannot be merged and stop the migration.
+// Every change is logged.
+func dedupeRoomSchedules(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.RoomSchedule{}) {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var roomSchedules []struct {
+			ID     uint
+			RoomID uint
+			TimeID uint
+			Date   time.Time
+		}
+		err := tx.Raw(`SELECT id, room_id, time_id, date FROM room_schedules AS schedule
+			WHERE EXISTS (
+				SELECT 1 FROM room_schedules AS other
+				WHERE other.room_id = schedule.room_id AND other.time_id = schedule.time_id
+					AND other.date = schedule.date AND other.id <> schedule.id
+			)
+			ORDER BY room_id, time_id, date, id`).Scan(&roomSchedules).Error
+		if err != nil {
+			return err
+		}
+
+		if len(roomSchedules) == 0 {
+			return nil
+		}
+
+		hasBookings := tx.Migrator().HasTable(&models.BookingSchedule{})
+		hasWaitlist := tx.Migrator().HasTable(&models.WaitlistEntry{})
+
+		waiting := []constants.WaitlistStatus{constants.WaitlistWaiting, constants.WaitlistOffered}
+		isBooked := make(map[uint]bool)
+		if hasBookings {
+			ids := make([]uint, 0, len(roomSchedules))
+			for _, roomSchedule := range roomSchedules {
+				ids = append(ids, roomSchedule.ID)
+			}
+
+			var booked []uint
+			err = tx.Raw(`SELECT DISTINCT room_schedule_id FROM booking_schedules
+				WHERE released_at IS NULL AND room_schedule_id IN ?`, ids).Scan(&booked).Error
+			if err != nil {
+				return err
+			}
+
+			for _, id := range booked {
+				isBooked[id] = true
+			}
+		}
+
+		for start := 0; start < len(roomSchedules); {
+			first := roomSchedules[start]
+			end := start + 1
+			for end < len(roomSchedules) && roomSchedules[end].RoomID == first.RoomID &&
+				roomSchedules[end].TimeID == first.TimeID && roomSchedules[end].Date.Equal(first.Date) {
+				end++
+			}
+			group := roomSchedules[start:end]
+			start = end
+
+			date := first.Date.Format(time.DateOnly)
+			kept := group[0].ID
+			var booked []uint
+			for _, roomSchedule := range group {
+				if isBooked[roomSchedule.ID] {
+					booked = append(booked, roomSchedule.ID)
+				}
+			}
+			if len(booked) > 1 {
+				return fmt.Errorf("room schedules %v of room %d, time %d on %s are all booked; release all but one booking before migrating", booked, first.RoomID, first.TimeID, date)
+			}
+			if len(booked) == 1 {
+				kept = booked[0]
+			}
+
+			for _, roomSchedule := range group {
+				if roomSchedule.ID == kept {
+					continue
+				}
+
+				if hasBookings {
+					err = tx.Exec("UPDATE booking_schedules SET room_schedule_id = ? WHERE room_schedule_id = ?", kept, roomSchedule.ID).Error
+					if err != nil {
+						return err
+					}
+				}
+
+				if hasWaitlist {
+					err = tx.Exec(`UPDATE waitlist_entries SET status = ?
+						WHERE room_schedule_id = ? AND status IN ? AND user_id IN (
+							SELECT user_id FROM waitlist_entries WHERE room_schedule_id = ? AND status IN ?
+						)`,
+						constants.WaitlistCancelled, roomSchedule.ID, waiting,
+						kept, waiting,
+					).Error
+					if err != nil {
+						return err
+					}
+
+					err = tx.Exec("UPDATE waitlist_entries SET room_schedule_id = ? WHERE room_schedule_id = ?", kept, roomSchedule.ID).Error
+					if err != nil {
+						return err
+					}
+				}
+
+				err = tx.Exec("DELETE FROM room_schedules WHERE id = ?", roomSchedule.ID).Error
+				if err != nil {
+					return err
+				}
+
+				logrus.Warnf("room schedule %d duplicated %d for room %d, time %d on %s and was merged into it", roomSchedule.ID, kept, first.RoomID, first.TimeID, date)
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"room-service/domain/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// convertTimeColumnsToTime turns the start and end of time slots from time
+// with time zone into plain time, keeping the clock time as written.
+func convertTimeColumnsToTime(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.Time{}) {
+		return nil
+	}
+
+	columnTypes, err := db.Migrator().ColumnTypes(&models.Time{})
+	if err != nil {
+		return err
+	}
+
+	var columns []string
+	for _, columnType := range columnTypes {
+		name := columnType.Name()
+		if name != "start_time" && name != "end_time" {
+			continue
+		}
+
+		typeName := strings.ToLower(columnType.DatabaseTypeName())
+		if typeName == "timetz" || strings.Contains(typeName, "with time zone") {
+			columns = append(columns, name)
+		}
+	}
+
+	if len(columns) == 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, column := range columns {
+			err := tx.Exec("ALTER TABLE times ALTER COLUMN " + column + " TYPE time USING " + column + "::time").Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "room-service/common/error"
+	errConstant "room-service/constants/error"
+	errAmenity "room-service/constants/error/amenity"
+	"room-service/domain/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AmenityRepository struct {
+	db *gorm.DB
+}
+
+type IAmenityRepository interface {
+	FindAll(context.Context) ([]models.Amenity, error)
+	FindAllByUUIDs(context.Context, []string) ([]models.Amenity, error)
+	FindByUUID(context.Context, string) (*models.Amenity, error)
+	Create(context.Context, *models.Amenity) (*models.Amenity, error)
+	Update(context.Context, string, *models.Amenity) (*models.Amenity, error)
+	Delete(context.Context, string) error
+}
+
+func NewAmenityRepository(db *gorm.DB) IAmenityRepository {
+	return &AmenityRepository{db: db}
+}
+
+func (a *AmenityRepository) FindAll(ctx context.Context) ([]models.Amenity, error) {
+	var amenities []models.Amenity
+	err := a.db.WithContext(ctx).Order("name asc").Find(&amenities).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return amenities, nil
+}
+
+func (a *AmenityRepository) FindAllByUUIDs(ctx context.Context, uuids []string) ([]models.Amenity, error) {
+	var amenities []models.Amenity
+	err := a.db.WithContext(ctx).Where("uuid::text IN ?", uuids).Find(&amenities).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return amenities, nil
+}
+
+func (a *AmenityRepository) FindByUUID(ctx context.Context, uuid string) (*models.Amenity, error) {
+	var amenity models.Amenity
+	err := a.db.WithContext(ctx).Where("uuid = ?", uuid).First(&amenity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errAmenity.ErrAmenityNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &amenity, nil
+}
+
+func (a *AmenityRepository) Create(ctx context.Context, req *models.Amenity) (*models.Amenity, error) {
+	req.UUID = uuid.New()
+	err := a.db.WithContext(ctx).Create(req).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errWrap.WrapError(errAmenity.ErrAmenityIsExist)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return req, nil
+}
+
+func (a *AmenityRepository) Update(ctx context.Context, uuid string, req *models.Amenity) (*models.Amenity, error) {
+	var amenity models.Amenity
+	err := a.db.
+		WithContext(ctx).
+		Model(&amenity).
+		Clauses(clause.Returning{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]interface{}{
+			"name":        req.Name,
+			"description": req.Description,
+		}).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errWrap.WrapError(errAmenity.ErrAmenityIsExist)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &amenity, nil
+}
+
+func (a *AmenityRepository) Delete(ctx context.Context, uuid string) error {
+	err := a.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.Amenity{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -0,0 +1,479 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	errWrap "room-service/common/error"
+	"room-service/constants"
+	errConstant "room-service/constants/error"
+	errBooking "room-service/constants/error/booking"
+	errRoomSchedule "room-service/constants/error/roomSchedule"
+	"room-service/domain/dto"
+	"room-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookingRepository struct {
+	db *gorm.DB
+}
+
+type IBookingRepository interface {
+	FindAllWithPagination(context.Context, *dto.BookingRequestParam, string) ([]models.Booking, int64, error)
+	FindAllByUserID(context.Context, *dto.BookingRequestParam, uuid.UUID) ([]models.Booking, int64, error)
+	FindAllPendingWithPagination(context.Context, *dto.BookingRequestParam, string) ([]models.Booking, int64, error)
+	FindByUUID(context.Context, string) (*models.Booking, error)
+	FindByUUIDForUpdate(context.Context, *gorm.DB, string) (*models.Booking, error)
+	FindActiveByRoomScheduleID(context.Context, *gorm.DB, uint) (*models.Booking, error)
+	FindActiveTimesByUserIDAndDate(context.Context, *gorm.DB, uuid.UUID, string) ([]models.Time, error)
+	FindAllActiveByRoomIDAndDateRange(context.Context, uint, time.Time, time.Time) ([]models.Booking, error)
+	FindAllActiveByUserIDAndDateRange(context.Context, uuid.UUID, time.Time, time.Time) ([]models.Booking, error)
+	CountActiveByUserID(context.Context, *gorm.DB, uuid.UUID, time.Time) (int64, error)
+	CountNoShowsByUserID(context.Context, *gorm.DB, uuid.UUID, time.Time) (int64, error)
+	FindDueForCheckInByRoomID(context.Context, *gorm.DB, uint, time.Time, time.Time) (*models.Booking, error)
+	FindAllMissedCheckIn(context.Context, time.Time) ([]models.Booking, error)
+	LockUser(context.Context, *gorm.DB, uuid.UUID) error
+	Create(context.Context, *gorm.DB, *models.Booking) (*models.Booking, error)
+	UpdateStatus(context.Context, *gorm.DB, constants.BookingStatus, string) error
+	Cancel(context.Context, *gorm.DB, string, uuid.UUID, string) error
+	Review(context.Context, *gorm.DB, string, constants.BookingStatus, uuid.UUID, string) error
+	ReleaseSchedules(context.Context, *gorm.DB, uint) error
+	CheckIn(context.Context, *gorm.DB, string, *uuid.UUID) error
+	MarkNoShow(context.Context, *gorm.DB, string) error
+}
+
+func NewBookingRepository(db *gorm.DB) IBookingRepository {
+	return &BookingRepository{db: db}
+}
+
+func (b *BookingRepository) scopeLibrary(library string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if library == "" {
+			return db
+		}
+		rooms := b.db.Model(&models.Room{}).Select("id").Where("library = ?", library)
+		return db.Where("room_id IN (?)", rooms)
+	}
+}
+
+func (b *BookingRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Room").
+		Preload("BookingSchedules.RoomSchedule.Time")
+}
+
+func (b *BookingRepository) paginate(ctx context.Context, param *dto.BookingRequestParam, scopes ...func(*gorm.DB) *gorm.DB) ([]models.Booking, int64, error) {
+	var (
+		bookings []models.Booking
+		sort     string
+		total    int64
+	)
+
+	if param.SortColumn != nil {
+		order := "asc"
+		if param.SortOrder != nil {
+			order = *param.SortOrder
+		}
+		sort = fmt.Sprintf("%s %s", *param.SortColumn, order)
+	} else {
+		sort = "created_at desc"
+	}
+
+	limit := param.Limit
+	offset := (param.Page - 1) * limit
+	err := b.db.
+		WithContext(ctx).
+		Scopes(b.preload).
+		Scopes(scopes...).
+		Limit(limit).
+		Offset(offset).
+		Order(sort).
+		Find(&bookings).
+		Error
+
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	err = b.db.
+		WithContext(ctx).
+		Model(&bookings).
+		Scopes(scopes...).
+		Count(&total).
+		Error
+
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return bookings, total, nil
+}
+
+func (b *BookingRepository) FindAllWithPagination(ctx context.Context, param *dto.BookingRequestParam, library string) ([]models.Booking, int64, error) {
+	return b.paginate(ctx, param, b.scopeLibrary(library))
+}
+
+func (b *BookingRepository) FindAllByUserID(ctx context.Context, param *dto.BookingRequestParam, userID uuid.UUID) ([]models.Booking, int64, error) {
+	return b.paginate(ctx, param, func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ?", userID)
+	})
+}
+
+func (b *BookingRepository) FindAllPendingWithPagination(ctx context.Context, param *dto.BookingRequestParam, library string) ([]models.Booking, int64, error) {
+	return b.paginate(ctx, param, b.scopeLibrary(library), func(db *gorm.DB) *gorm.DB {
+		return db.Where("status = ?", constants.BookingPending)
+	})
+}
+
+func (b *BookingRepository) FindByUUID(ctx context.Context, uuid string) (*models.Booking, error) {
+	var booking models.Booking
+	err := b.db.
+		WithContext(ctx).
+		Scopes(b.preload).
+		Where("uuid = ?", uuid).
+		First(&booking).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errBooking.ErrBookingNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &booking, nil
+}
+
+func (b *BookingRepository) FindByUUIDForUpdate(ctx context.Context, tx *gorm.DB, uuid string) (*models.Booking, error) {
+	var booking models.Booking
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Room").
+		Preload("BookingSchedules", "released_at IS NULL").
+		Preload("BookingSchedules.RoomSchedule.Time").
+		Where("uuid = ?", uuid).
+		First(&booking).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errBooking.ErrBookingNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &booking, nil
+}
+
+// FindActiveByRoomScheduleID returns the booking currently holding the slot.
+func (b *BookingRepository) FindActiveByRoomScheduleID(ctx context.Context, tx *gorm.DB, roomScheduleID uint) (*models.Booking, error) {
+	var booking models.Booking
+	err := tx.
+		WithContext(ctx).
+		Where("id = (?)", tx.
+			Model(&models.BookingSchedule{}).
+			Select("booking_id").
+			Where("room_schedule_id = ?", roomScheduleID).
+			Where("released_at IS NULL")).
+		First(&booking).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errBooking.ErrBookingNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &booking, nil
+}
+
+// activeStatuses are the booking statuses that still hold their slots.
+var activeStatuses = []constants.BookingStatus{constants.BookingConfirmed, constants.BookingPending, constants.BookingCheckedIn}
+
+// timestampLayout formats local times for comparison with the date and time
+// of a slot, which are stored without a time zone.
+const timestampLayout = "2006-01-02 15:04:05"
+
+// startsAt selects when the booking's earliest held slot starts.
+func (b *BookingRepository) startsAt(db *gorm.DB) *gorm.DB {
+	return db.
+		Model(&models.BookingSchedule{}).
+		Select("MIN(room_schedules.date + times.start_time)").
+		Joins("JOIN room_schedules ON room_schedules.id = booking_schedules.room_schedule_id").
+		Joins("JOIN times ON times.id = room_schedules.time_id").
+		Where("booking_schedules.booking_id = bookings.id").
+		Where("booking_schedules.released_at IS NULL")
+}
+
+// FindActiveTimesByUserIDAndDate returns the time slot of every schedule the
+// user's active bookings hold on the date.
+func (b *BookingRepository) FindActiveTimesByUserIDAndDate(ctx context.Context, tx *gorm.DB, userID uuid.UUID, date string) ([]models.Time, error) {
+	var times []models.Time
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Time{}).
+		Joins("JOIN room_schedules ON room_schedules.time_id = times.id").
+		Joins("JOIN booking_schedules ON booking_schedules.room_schedule_id = room_schedules.id").
+		Joins("JOIN bookings ON bookings.id = booking_schedules.booking_id").
+		Where("bookings.user_id = ?", userID).
+		Where("bookings.status IN ?", activeStatuses).
+		Where("booking_schedules.released_at IS NULL").
+		Where("room_schedules.date = ?", date).
+		Find(&times).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return times, nil
+}
+
+// findAllActiveInDateRange returns the active bookings matched by scope that
+// hold a slot between the two dates, with only their held slots preloaded.
+func (b *BookingRepository) findAllActiveInDateRange(ctx context.Context, startDate, endDate time.Time, scope func(*gorm.DB) *gorm.DB) ([]models.Booking, error) {
+	var bookings []models.Booking
+	inRange := b.db.
+		Model(&models.BookingSchedule{}).
+		Select("1").
+		Joins("JOIN room_schedules ON room_schedules.id = booking_schedules.room_schedule_id").
+		Where("booking_schedules.booking_id = bookings.id").
+		Where("booking_schedules.released_at IS NULL").
+		Where("room_schedules.date BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly))
+	err := b.db.
+		WithContext(ctx).
+		Preload("Room").
+		Preload("BookingSchedules", "released_at IS NULL").
+		Preload("BookingSchedules.RoomSchedule.Time").
+		Scopes(scope).
+		Where("status IN ?", activeStatuses).
+		Where("EXISTS (?)", inRange).
+		Order("created_at").
+		Find(&bookings).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return bookings, nil
+}
+
+func (b *BookingRepository) FindAllActiveByRoomIDAndDateRange(ctx context.Context, roomID uint, startDate, endDate time.Time) ([]models.Booking, error) {
+	return b.findAllActiveInDateRange(ctx, startDate, endDate, func(db *gorm.DB) *gorm.DB {
+		return db.Where("room_id = ?", roomID)
+	})
+}
+
+func (b *BookingRepository) FindAllActiveByUserIDAndDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]models.Booking, error) {
+	return b.findAllActiveInDateRange(ctx, startDate, endDate, func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ?", userID)
+	})
+}
+
+// CountActiveByUserID counts the user's active bookings that still hold a
+// slot on or after the given date.
+func (b *BookingRepository) CountActiveByUserID(ctx context.Context, tx *gorm.DB, userID uuid.UUID, from time.Time) (int64, error) {
+	var count int64
+	upcoming := tx.
+		Model(&models.BookingSchedule{}).
+		Select("1").
+		Joins("JOIN room_schedules ON room_schedules.id = booking_schedules.room_schedule_id").
+		Where("booking_schedules.booking_id = bookings.id").
+		Where("booking_schedules.released_at IS NULL").
+		Where("room_schedules.date >= ?", from.Format(time.DateOnly))
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Booking{}).
+		Where("user_id = ?", userID).
+		Where("status IN ?", activeStatuses).
+		Where("EXISTS (?)", upcoming).
+		Count(&count).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return count, nil
+}
+
+// CountNoShowsByUserID counts the user's bookings marked as no-show since
+// the given time.
+func (b *BookingRepository) CountNoShowsByUserID(ctx context.Context, tx *gorm.DB, userID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Booking{}).
+		Where("user_id = ?", userID).
+		Where("status = ?", constants.BookingNoShow).
+		Where("updated_at >= ?", since).
+		Count(&count).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return count, nil
+}
+
+// FindDueForCheckInByRoomID returns the confirmed booking of the room that
+// starts between the two times.
+func (b *BookingRepository) FindDueForCheckInByRoomID(ctx context.Context, tx *gorm.DB, roomID uint, from, to time.Time) (*models.Booking, error) {
+	var booking models.Booking
+	err := tx.
+		WithContext(ctx).
+		Where("room_id = ?", roomID).
+		Where("status = ?", constants.BookingConfirmed).
+		Where("(?) BETWEEN ? AND ?", b.startsAt(tx), from.Format(timestampLayout), to.Format(timestampLayout)).
+		First(&booking).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errBooking.ErrBookingNoneToCheckIn)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &booking, nil
+}
+
+// FindAllMissedCheckIn returns the confirmed bookings that started before the
+// given time without being checked in.
+func (b *BookingRepository) FindAllMissedCheckIn(ctx context.Context, startedBefore time.Time) ([]models.Booking, error) {
+	var bookings []models.Booking
+	err := b.db.
+		WithContext(ctx).
+		Where("status = ?", constants.BookingConfirmed).
+		Where("(?) < ?", b.startsAt(b.db), startedBefore.Format(timestampLayout)).
+		Find(&bookings).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return bookings, nil
+}
+
+// LockUser serializes the user's bookings until tx ends, so that concurrent
+// requests cannot slip past their quota together.
+func (b *BookingRepository) LockUser(ctx context.Context, tx *gorm.DB, userID uuid.UUID) error {
+	err := tx.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", userID.String()).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (b *BookingRepository) Create(ctx context.Context, tx *gorm.DB, req *models.Booking) (*models.Booking, error) {
+	req.UUID = uuid.New()
+	err := tx.WithContext(ctx).Create(req).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errWrap.WrapError(errRoomSchedule.ErrRoomScheduleAlreadyBooked)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return req, nil
+}
+
+func (b *BookingRepository) UpdateStatus(ctx context.Context, tx *gorm.DB, status constants.BookingStatus, uuid string) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Booking{}).
+		Where("uuid = ?", uuid).
+		Update("status", status).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (b *BookingRepository) Cancel(ctx context.Context, tx *gorm.DB, uuid string, cancelledBy uuid.UUID, reason string) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Booking{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{
+			"status":              constants.BookingCancelled,
+			"cancelled_at":        time.Now(),
+			"cancelled_by":        cancelledBy,
+			"cancellation_reason": reason,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (b *BookingRepository) Review(ctx context.Context, tx *gorm.DB, uuid string, status constants.BookingStatus, reviewedBy uuid.UUID, note string) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Booking{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{
+			"status":      status,
+			"reviewed_at": time.Now(),
+			"reviewed_by": reviewedBy,
+			"review_note": note,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (b *BookingRepository) ReleaseSchedules(ctx context.Context, tx *gorm.DB, bookingID uint) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.BookingSchedule{}).
+		Where("booking_id = ?", bookingID).
+		Where("released_at IS NULL").
+		Update("released_at", time.Now()).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (b *BookingRepository) CheckIn(ctx context.Context, tx *gorm.DB, uuid string, checkedInBy *uuid.UUID) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Booking{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{
+			"status":        constants.BookingCheckedIn,
+			"checked_in_at": time.Now(),
+			"checked_in_by": checkedInBy,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (b *BookingRepository) MarkNoShow(ctx context.Context, tx *gorm.DB, uuid string) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Booking{}).
+		Where("uuid = ?", uuid).
+		Update("status", constants.BookingNoShow).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
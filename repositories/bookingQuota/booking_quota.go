@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "room-service/common/error"
+	errConstant "room-service/constants/error"
+	errBookingQuota "room-service/constants/error/bookingQuota"
+	"room-service/domain/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookingQuotaRepository struct {
+	db *gorm.DB
+}
+
+type IBookingQuotaRepository interface {
+	FindAll(context.Context) ([]models.BookingQuota, error)
+	FindByUUID(context.Context, string) (*models.BookingQuota, error)
+	FindByUser(context.Context, *gorm.DB, uuid.UUID, string) (*models.BookingQuota, error)
+	Create(context.Context, *models.BookingQuota) (*models.BookingQuota, error)
+	Update(context.Context, string, *models.BookingQuota) (*models.BookingQuota, error)
+	Delete(context.Context, string) error
+}
+
+func NewBookingQuotaRepository(db *gorm.DB) IBookingQuotaRepository {
+	return &BookingQuotaRepository{db: db}
+}
+
+func (b *BookingQuotaRepository) FindAll(ctx context.Context) ([]models.BookingQuota, error) {
+	var quotas []models.BookingQuota
+	err := b.db.WithContext(ctx).Order("role asc").Order("user_id asc nulls first").Find(&quotas).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return quotas, nil
+}
+
+func (b *BookingQuotaRepository) FindByUUID(ctx context.Context, uuid string) (*models.BookingQuota, error) {
+	var quota models.BookingQuota
+	err := b.db.WithContext(ctx).Where("uuid = ?", uuid).First(&quota).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errBookingQuota.ErrBookingQuotaNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &quota, nil
+}
+
+// FindByUser returns the quota of the user, falling back to the quota of the
+// role. It returns nil when neither exists.
+func (b *BookingQuotaRepository) FindByUser(ctx context.Context, tx *gorm.DB, userID uuid.UUID, role string) (*models.BookingQuota, error) {
+	var quotas []models.BookingQuota
+	err := tx.
+		WithContext(ctx).
+		Where("user_id = ? OR (user_id IS NULL AND role = ?)", userID, role).
+		Order("user_id asc nulls last").
+		Limit(1).
+		Find(&quotas).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if len(quotas) == 0 {
+		return nil, nil
+	}
+
+	return &quotas[0], nil
+}
+
+func (b *BookingQuotaRepository) Create(ctx context.Context, req *models.BookingQuota) (*models.BookingQuota, error) {
+	req.UUID = uuid.New()
+	err := b.db.WithContext(ctx).Create(req).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errWrap.WrapError(errBookingQuota.ErrBookingQuotaIsExist)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return req, nil
+}
+
+func (b *BookingQuotaRepository) Update(ctx context.Context, uuid string, req *models.BookingQuota) (*models.BookingQuota, error) {
+	var quota models.BookingQuota
+	err := b.db.
+		WithContext(ctx).
+		Model(&quota).
+		Clauses(clause.Returning{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]interface{}{
+			"role":                req.Role,
+			"user_id":             req.UserID,
+			"max_hours_per_day":   req.MaxHoursPerDay,
+			"max_active_bookings": req.MaxActiveBookings,
+			"max_days_in_advance": req.MaxDaysInAdvance,
+			"max_no_shows":        req.MaxNoShows,
+		}).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errWrap.WrapError(errBookingQuota.ErrBookingQuotaIsExist)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &quota, nil
+}
+
+func (b *BookingQuotaRepository) Delete(ctx context.Context, uuid string) error {
+	err := b.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.BookingQuota{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
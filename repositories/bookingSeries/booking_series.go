@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "room-service/common/error"
+	errConstant "room-service/constants/error"
+	errBooking "room-service/constants/error/booking"
+	"room-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookingSeriesRepository struct {
+	db *gorm.DB
+}
+
+type IBookingSeriesRepository interface {
+	FindAllByUserID(context.Context, uuid.UUID) ([]models.BookingSeries, error)
+	FindByUUID(context.Context, string) (*models.BookingSeries, error)
+	FindByUUIDForUpdate(context.Context, *gorm.DB, string) (*models.BookingSeries, error)
+	Create(context.Context, *gorm.DB, *models.BookingSeries) (*models.BookingSeries, error)
+	Cancel(context.Context, *gorm.DB, string, uuid.UUID) error
+}
+
+func NewBookingSeriesRepository(db *gorm.DB) IBookingSeriesRepository {
+	return &BookingSeriesRepository{db: db}
+}
+
+// preload loads every occurrence with all of its slots, released or not, so
+// that cancelled occurrences still show their date.
+func (b *BookingSeriesRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Room").
+		Preload("Bookings.BookingSchedules.RoomSchedule.Time")
+}
+
+func (b *BookingSeriesRepository) FindAllByUserID(ctx context.Context, userID uuid.UUID) ([]models.BookingSeries, error) {
+	var series []models.BookingSeries
+	err := b.db.
+		WithContext(ctx).
+		Scopes(b.preload).
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Find(&series).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return series, nil
+}
+
+func (b *BookingSeriesRepository) FindByUUID(ctx context.Context, uuid string) (*models.BookingSeries, error) {
+	var series models.BookingSeries
+	err := b.db.
+		WithContext(ctx).
+		Scopes(b.preload).
+		Where("uuid = ?", uuid).
+		First(&series).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errBooking.ErrBookingSeriesNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &series, nil
+}
+
+func (b *BookingSeriesRepository) FindByUUIDForUpdate(ctx context.Context, tx *gorm.DB, uuid string) (*models.BookingSeries, error) {
+	var series models.BookingSeries
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Scopes(b.preload).
+		Where("uuid = ?", uuid).
+		First(&series).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errBooking.ErrBookingSeriesNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &series, nil
+}
+
+func (b *BookingSeriesRepository) Create(ctx context.Context, tx *gorm.DB, req *models.BookingSeries) (*models.BookingSeries, error) {
+	req.UUID = uuid.New()
+	err := tx.WithContext(ctx).Create(req).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return req, nil
+}
+
+func (b *BookingSeriesRepository) Cancel(ctx context.Context, tx *gorm.DB, uuid string, cancelledBy uuid.UUID) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.BookingSeries{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{
+			"cancelled_at": time.Now(),
+			"cancelled_by": cancelledBy,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
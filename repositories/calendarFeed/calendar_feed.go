@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "room-service/common/error"
+	errConstant "room-service/constants/error"
+	errCalendarFeed "room-service/constants/error/calendarFeed"
+	"room-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CalendarFeedRepository struct {
+	db *gorm.DB
+}
+
+type ICalendarFeedRepository interface {
+	FindAllByCreatedBy(context.Context, uuid.UUID) ([]models.CalendarFeed, error)
+	FindByUUID(context.Context, string) (*models.CalendarFeed, error)
+	Create(context.Context, *models.CalendarFeed) (*models.CalendarFeed, error)
+	Revoke(context.Context, string) error
+}
+
+func NewCalendarFeedRepository(db *gorm.DB) ICalendarFeedRepository {
+	return &CalendarFeedRepository{db: db}
+}
+
+func (c *CalendarFeedRepository) FindAllByCreatedBy(ctx context.Context, createdBy uuid.UUID) ([]models.CalendarFeed, error) {
+	var feeds []models.CalendarFeed
+	err := c.db.
+		WithContext(ctx).
+		Preload("Room").
+		Where("created_by = ?", createdBy).
+		Where("revoked_at IS NULL").
+		Order("created_at desc").
+		Find(&feeds).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return feeds, nil
+}
+
+// FindByUUID returns the feed only while it has not been revoked.
+func (c *CalendarFeedRepository) FindByUUID(ctx context.Context, uuid string) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := c.db.
+		WithContext(ctx).
+		Preload("Room").
+		Where("uuid = ?", uuid).
+		Where("revoked_at IS NULL").
+		First(&feed).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errCalendarFeed.ErrCalendarFeedNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &feed, nil
+}
+
+func (c *CalendarFeedRepository) Create(ctx context.Context, req *models.CalendarFeed) (*models.CalendarFeed, error) {
+	req.UUID = uuid.New()
+	err := c.db.WithContext(ctx).Create(req).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return req, nil
+}
+
+func (c *CalendarFeedRepository) Revoke(ctx context.Context, uuid string) error {
+	err := c.db.
+		WithContext(ctx).
+		Model(&models.CalendarFeed{}).
+		Where("uuid = ?", uuid).
+		Update("revoked_at", time.Now()).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
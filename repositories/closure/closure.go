@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "room-service/common/error"
+	errConstant "room-service/constants/error"
+	errClosure "room-service/constants/error/closure"
+	"room-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ClosureRepository struct {
+	db *gorm.DB
+}
+
+type IClosureRepository interface {
+	FindAll(context.Context, string) ([]models.Closure, error)
+	FindAllByRoomAndDateRange(context.Context, *models.Room, time.Time, time.Time) ([]models.Closure, error)
+	FindByUUID(context.Context, string) (*models.Closure, error)
+	Create(context.Context, *gorm.DB, *models.Closure) (*models.Closure, error)
+	Update(context.Context, *gorm.DB, string, *models.Closure) error
+	Delete(context.Context, string) error
+}
+
+func NewClosureRepository(db *gorm.DB) IClosureRepository {
+	return &ClosureRepository{db: db}
+}
+
+func (c *ClosureRepository) FindAll(ctx context.Context, library string) ([]models.Closure, error) {
+	var closures []models.Closure
+	query := c.db.WithContext(ctx).Preload("Room")
+	if library != "" {
+		query = query.Where("library = ?", library)
+	}
+
+	err := query.Order("start_date desc").Find(&closures).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return closures, nil
+}
+
+// FindAllByRoomAndDateRange returns the closures of the room and of its whole
+// library that overlap the date range.
+func (c *ClosureRepository) FindAllByRoomAndDateRange(ctx context.Context, room *models.Room, startDate, endDate time.Time) ([]models.Closure, error) {
+	var closures []models.Closure
+	err := c.db.
+		WithContext(ctx).
+		Where("library = ?", room.Library).
+		Where("(room_id IS NULL OR room_id = ?)", room.ID).
+		Where("start_date <= ? AND end_date >= ?", endDate.Format(time.DateOnly), startDate.Format(time.DateOnly)).
+		Find(&closures).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return closures, nil
+}
+
+func (c *ClosureRepository) FindByUUID(ctx context.Context, uuid string) (*models.Closure, error) {
+	var closure models.Closure
+	err := c.db.
+		WithContext(ctx).
+		Preload("Room").
+		Where("uuid = ?", uuid).
+		First(&closure).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errClosure.ErrClosureNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &closure, nil
+}
+
+func (c *ClosureRepository) Create(ctx context.Context, tx *gorm.DB, req *models.Closure) (*models.Closure, error) {
+	req.UUID = uuid.New()
+	err := tx.WithContext(ctx).Create(req).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return req, nil
+}
+
+func (c *ClosureRepository) Update(ctx context.Context, tx *gorm.DB, uuid string, req *models.Closure) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Closure{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{
+			"room_id":    req.RoomID,
+			"start_date": req.StartDate,
+			"end_date":   req.EndDate,
+			"reason":     req.Reason,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (c *ClosureRepository) Delete(ctx context.Context, uuid string) error {
+	err := c.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.Closure{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "room-service/common/error"
+	errConstant "room-service/constants/error"
+	errPenalty "room-service/constants/error/penalty"
+	"room-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PenaltyRepository struct {
+	db *gorm.DB
+}
+
+type IPenaltyRepository interface {
+	FindAllStrikes(context.Context, string, *uuid.UUID) ([]models.Strike, error)
+	FindStrikeByUUIDForUpdate(context.Context, *gorm.DB, string) (*models.Strike, error)
+	CountActiveStrikes(context.Context, *gorm.DB, uuid.UUID, time.Time) (int64, error)
+	CreateStrike(context.Context, *gorm.DB, *models.Strike) (*models.Strike, error)
+	ClearStrike(context.Context, *gorm.DB, string, uuid.UUID) error
+	FindAllSuspensions(context.Context, string, *uuid.UUID) ([]models.Suspension, error)
+	FindSuspensionByUUIDForUpdate(context.Context, *gorm.DB, string) (*models.Suspension, error)
+	FindActiveSuspension(context.Context, *gorm.DB, uuid.UUID, time.Time) (*models.Suspension, error)
+	FindLastSuspension(context.Context, *gorm.DB, uuid.UUID) (*models.Suspension, error)
+	CreateSuspension(context.Context, *gorm.DB, *models.Suspension) (*models.Suspension, error)
+	LiftSuspension(context.Context, *gorm.DB, string, uuid.UUID) error
+}
+
+func NewPenaltyRepository(db *gorm.DB) IPenaltyRepository {
+	return &PenaltyRepository{db: db}
+}
+
+// scope limits a listing to the library and, when given, to one user.
+func (p *PenaltyRepository) scope(library string, userID *uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if library != "" {
+			db = db.Where("library = ?", library)
+		}
+		if userID != nil {
+			db = db.Where("user_id = ?", *userID)
+		}
+		return db
+	}
+}
+
+func (p *PenaltyRepository) FindAllStrikes(ctx context.Context, library string, userID *uuid.UUID) ([]models.Strike, error) {
+	var strikes []models.Strike
+	err := p.db.
+		WithContext(ctx).
+		Preload("Booking").
+		Scopes(p.scope(library, userID)).
+		Order("created_at desc").
+		Find(&strikes).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return strikes, nil
+}
+
+func (p *PenaltyRepository) FindStrikeByUUIDForUpdate(ctx context.Context, tx *gorm.DB, uuid string) (*models.Strike, error) {
+	var strike models.Strike
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Booking").
+		Where("uuid = ?", uuid).
+		First(&strike).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errPenalty.ErrStrikeNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &strike, nil
+}
+
+// CountActiveStrikes counts the user's uncleared strikes given since the
+// time.
+func (p *PenaltyRepository) CountActiveStrikes(ctx context.Context, tx *gorm.DB, userID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Strike{}).
+		Where("user_id = ?", userID).
+		Where("cleared_at IS NULL").
+		Where("created_at > ?", since).
+		Count(&count).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return count, nil
+}
+
+func (p *PenaltyRepository) CreateStrike(ctx context.Context, tx *gorm.DB, req *models.Strike) (*models.Strike, error) {
+	req.UUID = uuid.New()
+	err := tx.WithContext(ctx).Create(req).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return req, nil
+}
+
+func (p *PenaltyRepository) ClearStrike(ctx context.Context, tx *gorm.DB, uuid string, clearedBy uuid.UUID) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Strike{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{
+			"cleared_at": time.Now(),
+			"cleared_by": clearedBy,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (p *PenaltyRepository) FindAllSuspensions(ctx context.Context, library string, userID *uuid.UUID) ([]models.Suspension, error) {
+	var suspensions []models.Suspension
+	err := p.db.
+		WithContext(ctx).
+		Scopes(p.scope(library, userID)).
+		Order("created_at desc").
+		Find(&suspensions).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return suspensions, nil
+}
+
+func (p *PenaltyRepository) FindSuspensionByUUIDForUpdate(ctx context.Context, tx *gorm.DB, uuid string) (*models.Suspension, error) {
+	var suspension models.Suspension
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uuid = ?", uuid).
+		First(&suspension).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errPenalty.ErrSuspensionNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &suspension, nil
+}
+
+// FindActiveSuspension returns the suspension keeping the user from booking
+// at the given time, or nil when there is none.
+func (p *PenaltyRepository) FindActiveSuspension(ctx context.Context, tx *gorm.DB, userID uuid.UUID, at time.Time) (*models.Suspension, error) {
+	var suspensions []models.Suspension
+	err := tx.
+		WithContext(ctx).
+		Where("user_id = ?", userID).
+		Where("lifted_at IS NULL").
+		Where("ends_at > ?", at).
+		Order("ends_at desc").
+		Limit(1).
+		Find(&suspensions).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if len(suspensions) == 0 {
+		return nil, nil
+	}
+
+	return &suspensions[0], nil
+}
+
+// FindLastSuspension returns the user's most recent suspension, lifted or
+// not, or nil when the user was never suspended.
+func (p *PenaltyRepository) FindLastSuspension(ctx context.Context, tx *gorm.DB, userID uuid.UUID) (*models.Suspension, error) {
+	var suspensions []models.Suspension
+	err := tx.
+		WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Limit(1).
+		Find(&suspensions).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if len(suspensions) == 0 {
+		return nil, nil
+	}
+
+	return &suspensions[0], nil
+}
+
+func (p *PenaltyRepository) CreateSuspension(ctx context.Context, tx *gorm.DB, req *models.Suspension) (*models.Suspension, error) {
+	req.UUID = uuid.New()
+	err := tx.WithContext(ctx).Create(req).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return req, nil
+}
+
+func (p *PenaltyRepository) LiftSuspension(ctx context.Context, tx *gorm.DB, uuid string, liftedBy uuid.UUID) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Suspension{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{
+			"lifted_at": time.Now(),
+			"lifted_by": liftedBy,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
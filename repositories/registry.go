@@ -1,9 +1,18 @@
 package repositories
 
 import (
+	amenityRepo "room-service/repositories/amenity"
+	bookingRepo "room-service/repositories/booking"
+	bookingQuotaRepo "room-service/repositories/bookingQuota"
+	bookingSeriesRepo "room-service/repositories/bookingSeries"
+	calendarFeedRepo "room-service/repositories/calendarFeed"
+	closureRepo "room-service/repositories/closure"
+	penaltyRepo "room-service/repositories/penalty"
 	roomRepo "room-service/repositories/room"
 	roomScheduleRepo "room-service/repositories/roomSchedule"
+	roomSlotTemplateRepo "room-service/repositories/roomSlotTemplate"
 	timeRepo "room-service/repositories/time"
+	waitlistRepo "room-service/repositories/waitlist"
 
 	"gorm.io/gorm"
 )
@@ -13,15 +22,53 @@ type Registry struct {
 }
 
 type IRepositoryRegistry interface {
+	GetAmenity() amenityRepo.IAmenityRepository
+	GetBooking() bookingRepo.IBookingRepository
+	GetBookingQuota() bookingQuotaRepo.IBookingQuotaRepository
+	GetBookingSeries() bookingSeriesRepo.IBookingSeriesRepository
+	GetCalendarFeed() calendarFeedRepo.ICalendarFeedRepository
+	GetClosure() closureRepo.IClosureRepository
+	GetPenalty() penaltyRepo.IPenaltyRepository
 	GetRoom() roomRepo.IRoomRepository
 	GetRoomSchedule() roomScheduleRepo.IRoomScheduleRepository
+	GetRoomSlotTemplate() roomSlotTemplateRepo.IRoomSlotTemplateRepository
 	GetTime() timeRepo.ITimeRepository
+	GetWaitlist() waitlistRepo.IWaitlistRepository
+	GetTx() *gorm.DB
 }
 
 func NewRepositoryRegistry(db *gorm.DB) IRepositoryRegistry {
 	return &Registry{db: db}
 }
 
+func (r *Registry) GetAmenity() amenityRepo.IAmenityRepository {
+	return amenityRepo.NewAmenityRepository(r.db)
+}
+
+func (r *Registry) GetBooking() bookingRepo.IBookingRepository {
+	return bookingRepo.NewBookingRepository(r.db)
+}
+
+func (r *Registry) GetBookingQuota() bookingQuotaRepo.IBookingQuotaRepository {
+	return bookingQuotaRepo.NewBookingQuotaRepository(r.db)
+}
+
+func (r *Registry) GetBookingSeries() bookingSeriesRepo.IBookingSeriesRepository {
+	return bookingSeriesRepo.NewBookingSeriesRepository(r.db)
+}
+
+func (r *Registry) GetCalendarFeed() calendarFeedRepo.ICalendarFeedRepository {
+	return calendarFeedRepo.NewCalendarFeedRepository(r.db)
+}
+
+func (r *Registry) GetClosure() closureRepo.IClosureRepository {
+	return closureRepo.NewClosureRepository(r.db)
+}
+
+func (r *Registry) GetPenalty() penaltyRepo.IPenaltyRepository {
+	return penaltyRepo.NewPenaltyRepository(r.db)
+}
+
 func (r *Registry) GetRoom() roomRepo.IRoomRepository {
 	return roomRepo.NewRoomRepository(r.db)
 }
@@ -30,6 +77,18 @@ func (r *Registry) GetRoomSchedule() roomScheduleRepo.IRoomScheduleRepository {
 	return roomScheduleRepo.NewRoomScheduleRepository(r.db)
 }
 
+func (r *Registry) GetRoomSlotTemplate() roomSlotTemplateRepo.IRoomSlotTemplateRepository {
+	return roomSlotTemplateRepo.NewRoomSlotTemplateRepository(r.db)
+}
+
 func (r *Registry) GetTime() timeRepo.ITimeRepository {
 	return timeRepo.NewTimeRepository(r.db)
 }
+
+func (r *Registry) GetWaitlist() waitlistRepo.IWaitlistRepository {
+	return waitlistRepo.NewWaitlistRepository(r.db)
+}
+
+func (r *Registry) GetTx() *gorm.DB {
+	return r.db
+}
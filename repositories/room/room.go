@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	errWrap "room-service/common/error"
+	"room-service/common/util"
 	errConstant "room-service/constants/error"
 	errRoom "room-service/constants/error/room"
 	"room-service/domain/dto"
@@ -19,11 +20,14 @@ type RoomRepository struct {
 }
 
 type IRoomRepository interface {
-	FindAllWithPagination(context.Context, *dto.RoomRequestParam) ([]models.Room, int64, error)
-	FindAllWithoutPagination(context.Context) ([]models.Room, error)
+	FindAllWithPagination(context.Context, *dto.RoomRequestParam, string) ([]models.Room, int64, error)
+	FindAllWithoutPagination(context.Context, string, *dto.RoomFilterParam) ([]models.Room, error)
 	FindByUUID(context.Context, string) (*models.Room, error)
-	Create(context.Context, *models.Room) (*models.Room, error)
-	Update(context.Context, string, *models.Room) (*models.Room, error)
+	Create(context.Context, *gorm.DB, *models.Room) (*models.Room, error)
+	Update(context.Context, *gorm.DB, string, *models.Room) error
+	ReplaceAmenities(context.Context, *gorm.DB, *models.Room, []models.Amenity) error
+	Upsert(context.Context, *gorm.DB, *models.Room, []models.Amenity) error
+	UpdateCheckInToken(context.Context, string, string) error
 	Delete(context.Context, string) error
 }
 
@@ -31,7 +35,43 @@ func NewRoomRepository(db *gorm.DB) IRoomRepository {
 	return &RoomRepository{db: db}
 }
 
-func (f *RoomRepository) FindAllWithPagination(ctx context.Context, param *dto.RoomRequestParam) ([]models.Room, int64, error) {
+func (f *RoomRepository) scopeLibrary(library string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if library == "" {
+			return db
+		}
+		return db.Where("library = ?", library)
+	}
+}
+
+func (f *RoomRepository) scopeFilter(param *dto.RoomFilterParam) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if param == nil {
+			return db
+		}
+		if param.MinCapacity != nil {
+			db = db.Where("capacity >= ?", *param.MinCapacity)
+		}
+		if param.MaxCapacity != nil {
+			db = db.Where("capacity <= ?", *param.MaxCapacity)
+		}
+
+		amenities := util.SplitList(param.Amenities)
+		if len(amenities) > 0 {
+			rooms := f.db.
+				Table("room_amenities").
+				Select("room_amenities.room_id").
+				Joins("JOIN amenities ON amenities.id = room_amenities.amenity_id").
+				Where("amenities.uuid::text IN ? OR lower(amenities.name) IN ?", amenities, amenities).
+				Group("room_amenities.room_id").
+				Having("COUNT(DISTINCT amenities.id) = ?", len(amenities))
+			db = db.Where("id IN (?)", rooms)
+		}
+		return db
+	}
+}
+
+func (f *RoomRepository) FindAllWithPagination(ctx context.Context, param *dto.RoomRequestParam, library string) ([]models.Room, int64, error) {
 	var (
 		rooms []models.Room
 		sort  string
@@ -39,7 +79,11 @@ func (f *RoomRepository) FindAllWithPagination(ctx context.Context, param *dto.R
 	)
 
 	if param.SortColumn != nil {
-		sort = fmt.Sprintf("%s %s", *param.SortColumn, *param.SortOrder)
+		order := "asc"
+		if param.SortOrder != nil {
+			order = *param.SortOrder
+		}
+		sort = fmt.Sprintf("%s %s", *param.SortColumn, order)
 	} else {
 		sort = "created_at desc"
 	}
@@ -48,6 +92,8 @@ func (f *RoomRepository) FindAllWithPagination(ctx context.Context, param *dto.R
 	offset := (param.Page - 1) * limit
 	err := f.db.
 		WithContext(ctx).
+		Preload("Amenities").
+		Scopes(f.scopeLibrary(library), f.scopeFilter(&param.RoomFilterParam)).
 		Limit(limit).
 		Offset(offset).
 		Order(sort).
@@ -61,6 +107,7 @@ func (f *RoomRepository) FindAllWithPagination(ctx context.Context, param *dto.R
 	err = f.db.
 		WithContext(ctx).
 		Model(&rooms).
+		Scopes(f.scopeLibrary(library), f.scopeFilter(&param.RoomFilterParam)).
 		Count(&total).
 		Error
 
@@ -71,10 +118,12 @@ func (f *RoomRepository) FindAllWithPagination(ctx context.Context, param *dto.R
 	return rooms, total, nil
 }
 
-func (f *RoomRepository) FindAllWithoutPagination(ctx context.Context) ([]models.Room, error) {
+func (f *RoomRepository) FindAllWithoutPagination(ctx context.Context, library string, param *dto.RoomFilterParam) ([]models.Room, error) {
 	var rooms []models.Room
 	err := f.db.
 		WithContext(ctx).
+		Preload("Amenities").
+		Scopes(f.scopeLibrary(library), f.scopeFilter(param)).
 		Find(&rooms).
 		Error
 	if err != nil {
@@ -87,6 +136,7 @@ func (f *RoomRepository) FindByUUID(ctx context.Context, uuid string) (*models.R
 	var room models.Room
 	err := f.db.
 		WithContext(ctx).
+		Preload("Amenities").
 		Where("uuid = ?", uuid).
 		First(&room).
 		Error
@@ -100,39 +150,106 @@ func (f *RoomRepository) FindByUUID(ctx context.Context, uuid string) (*models.R
 	return &room, nil
 }
 
-func (f *RoomRepository) Create(ctx context.Context, req *models.Room) (*models.Room, error) {
+func (f *RoomRepository) Create(ctx context.Context, tx *gorm.DB, req *models.Room) (*models.Room, error) {
 	room := models.Room{
-		UUID:        uuid.New(),
-		Code:        req.Code,
-		Name:        req.Name,
-		Capacity:    req.Capacity,
-		Description: req.Description,
-		Image:       req.Image,
+		UUID:             uuid.New(),
+		Library:          req.Library,
+		Code:             req.Code,
+		Name:             req.Name,
+		Capacity:         req.Capacity,
+		Description:      req.Description,
+		RequiresApproval: req.RequiresApproval,
+		Image:            req.Image,
 	}
 
-	err := f.db.WithContext(ctx).Create(&room).Error
+	err := tx.WithContext(ctx).Create(&room).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errWrap.WrapError(errRoom.ErrRoomCodeExists)
+		}
 		return nil, errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
 	return &room, nil
 }
 
-func (f *RoomRepository) Update(ctx context.Context, uuid string, req *models.Room) (*models.Room, error) {
+func (f *RoomRepository) Update(ctx context.Context, tx *gorm.DB, uuid string, req *models.Room) error {
 	room := models.Room{
-		Code:        req.Code,
-		Name:        req.Name,
-		Capacity:    req.Capacity,
-		Description: req.Description,
-		Image:       req.Image,
+		Code:             req.Code,
+		Name:             req.Name,
+		Capacity:         req.Capacity,
+		Description:      req.Description,
+		RequiresApproval: req.RequiresApproval,
+		Image:            req.Image,
 	}
 
-	err := f.db.WithContext(ctx).Where("uuid = ?", uuid).Updates(&room).Error
+	err := tx.
+		WithContext(ctx).
+		Select("code", "name", "capacity", "description", "image", "requires_approval").
+		Where("uuid = ?", uuid).
+		Updates(&room).
+		Error
 	if err != nil {
-		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errWrap.WrapError(errRoom.ErrRoomCodeExists)
+		}
+		return errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
-	return &room, nil
+	return nil
+}
+
+func (f *RoomRepository) ReplaceAmenities(ctx context.Context, tx *gorm.DB, room *models.Room, amenities []models.Amenity) error {
+	err := tx.WithContext(ctx).Model(room).Association("Amenities").Replace(amenities)
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// Upsert creates the room when it has no ID yet and updates it otherwise,
+// replacing its amenities in the same transaction.
+func (f *RoomRepository) Upsert(ctx context.Context, tx *gorm.DB, room *models.Room, amenities []models.Amenity) error {
+	var err error
+	if room.ID == 0 {
+		room.UUID = uuid.New()
+		err = tx.WithContext(ctx).Omit("Amenities").Create(room).Error
+	} else {
+		err = tx.
+			WithContext(ctx).
+			Model(room).
+			Select("code", "name", "capacity", "description", "image", "requires_approval").
+			Updates(room).
+			Error
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errWrap.WrapError(errRoom.ErrRoomCodeExists)
+		}
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	err = tx.WithContext(ctx).Model(room).Association("Amenities").Replace(amenities)
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (f *RoomRepository) UpdateCheckInToken(ctx context.Context, uuid, token string) error {
+	err := f.db.
+		WithContext(ctx).
+		Model(&models.Room{}).
+		Where("uuid = ?", uuid).
+		Update("check_in_token", token).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
 }
 
 func (f *RoomRepository) Delete(ctx context.Context, uuid string) error {
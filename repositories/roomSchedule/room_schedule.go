@@ -5,13 +5,17 @@ import (
 	"errors"
 	"fmt"
 	errWrap "room-service/common/error"
+	"room-service/common/util"
 	constans "room-service/constants"
 	errConstant "room-service/constants/error"
 	errRoomSchedule "room-service/constants/error/roomSchedule"
 	"room-service/domain/dto"
 	"room-service/domain/models"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type RoomScheduleRepository struct {
@@ -19,21 +23,43 @@ type RoomScheduleRepository struct {
 }
 
 type IRoomScheduleRepository interface {
-	FindAllWithPagination(context.Context, *dto.RoomScheduleRequestParam) ([]models.RoomSchedule, int64, error)
+	FindAllWithPagination(context.Context, *dto.RoomScheduleRequestParam, string) ([]models.RoomSchedule, int64, error)
 	FindAllByRoomIDAndDate(context.Context, int, string) ([]models.RoomSchedule, error)
+	FindAllAvailable(context.Context, string, *dto.AvailabilitySearchParam) ([]models.RoomSchedule, error)
 	FindByUUID(context.Context, string) (*models.RoomSchedule, error)
+	FindByIDForUpdate(context.Context, *gorm.DB, uint) (*models.RoomSchedule, error)
+	FindAllByUUIDsForUpdate(context.Context, *gorm.DB, []string) ([]models.RoomSchedule, error)
+	FindAllByRoomIDAndTimeRangeForUpdate(context.Context, *gorm.DB, uint, string, string, string) ([]models.RoomSchedule, error)
 	FindByDateAndTimeID(context.Context, string, int, int) (*models.RoomSchedule, error)
+	FindAllByRoomIDAndDateRange(context.Context, uint, time.Time, time.Time) ([]models.RoomSchedule, error)
+	FindAllByDateRangeForUpdate(context.Context, *gorm.DB, string, *uint, time.Time, time.Time) ([]models.RoomSchedule, error)
+	FindAllByTimeIDForUpdate(context.Context, *gorm.DB, uint) ([]models.RoomSchedule, error)
+	CountByTimeID(context.Context, uint, []constans.RoomScheduleStatus) (int64, error)
 	Create(context.Context, []models.RoomSchedule) error
-	Update(context.Context, string, *models.RoomSchedule) (*models.RoomSchedule, error)
-	UpdateStatus(context.Context, constans.RoomScheduleStatus, string) error
+	Update(context.Context, *gorm.DB, string, *models.RoomSchedule) error
+	UpdateStatus(context.Context, *gorm.DB, constans.RoomScheduleStatus, string) error
+	Hold(context.Context, *gorm.DB, string, uuid.UUID, time.Time) error
+	CountHeldByUserID(context.Context, *gorm.DB, uuid.UUID, time.Time) (int64, error)
+	FindAllExpiredHoldsForUpdate(context.Context, *gorm.DB, time.Time) ([]models.RoomSchedule, error)
 	Delete(context.Context, string) error
+	DeleteByTimeID(context.Context, *gorm.DB, uint) error
 }
 
 func NewRoomScheduleRepository(db *gorm.DB) IRoomScheduleRepository {
 	return &RoomScheduleRepository{db: db}
 }
 
-func (f *RoomScheduleRepository) FindAllWithPagination(ctx context.Context, param *dto.RoomScheduleRequestParam) ([]models.RoomSchedule, int64, error) {
+func (f *RoomScheduleRepository) scopeLibrary(library string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if library == "" {
+			return db
+		}
+		rooms := f.db.Model(&models.Room{}).Select("id").Where("library = ?", library)
+		return db.Where("room_id IN (?)", rooms)
+	}
+}
+
+func (f *RoomScheduleRepository) FindAllWithPagination(ctx context.Context, param *dto.RoomScheduleRequestParam, library string) ([]models.RoomSchedule, int64, error) {
 	var (
 		roomSchedules []models.RoomSchedule
 		sort          string
@@ -41,7 +67,11 @@ func (f *RoomScheduleRepository) FindAllWithPagination(ctx context.Context, para
 	)
 
 	if param.SortColumn != nil {
-		sort = fmt.Sprintf("%s %s", *param.SortColumn, *param.SortOrder)
+		order := "asc"
+		if param.SortOrder != nil {
+			order = *param.SortOrder
+		}
+		sort = fmt.Sprintf("%s %s", *param.SortColumn, order)
 	} else {
 		sort = "created_at desc"
 	}
@@ -52,6 +82,7 @@ func (f *RoomScheduleRepository) FindAllWithPagination(ctx context.Context, para
 		WithContext(ctx).
 		Preload("Room").
 		Preload("Time").
+		Scopes(f.scopeLibrary(library)).
 		Limit(limit).
 		Offset(offset).
 		Order(sort).
@@ -65,6 +96,7 @@ func (f *RoomScheduleRepository) FindAllWithPagination(ctx context.Context, para
 	err = f.db.
 		WithContext(ctx).
 		Model(&roomSchedules).
+		Scopes(f.scopeLibrary(library)).
 		Count(&total).
 		Error
 
@@ -93,6 +125,57 @@ func (f *RoomScheduleRepository) FindAllByRoomIDAndDate(ctx context.Context, roo
 	return roomSchedules, nil
 }
 
+// FindAllAvailable returns the available schedules matching the search, with
+// their room and time, ordered by room, date and start time. Rooms and times
+// are joined in the same query.
+func (f *RoomScheduleRepository) FindAllAvailable(ctx context.Context, library string, param *dto.AvailabilitySearchParam) ([]models.RoomSchedule, error) {
+	var roomSchedules []models.RoomSchedule
+	query := f.db.
+		WithContext(ctx).
+		Joins("Room").
+		Joins("Time").
+		Where("room_schedules.status = ?", constans.Available).
+		Where("room_schedules.date BETWEEN ? AND ?", param.StartDate, param.EndDate).
+		Where(`"Room".id IS NOT NULL AND "Room".deleted_at IS NULL`)
+	if library != "" {
+		query = query.Where(`"Room".library = ?`, library)
+	}
+	if param.StartTime != "" {
+		query = query.Where(`"Time".start_time >= ?`, param.StartTime)
+	}
+	if param.EndTime != "" {
+		query = query.Where(`"Time".end_time <= ?`, param.EndTime)
+	}
+	if param.Capacity > 0 {
+		query = query.Where(`"Room".capacity >= ?`, param.Capacity)
+	}
+
+	amenities := util.SplitList(param.Amenities)
+	if len(amenities) > 0 {
+		rooms := f.db.
+			Table("room_amenities").
+			Select("room_amenities.room_id").
+			Joins("JOIN amenities ON amenities.id = room_amenities.amenity_id").
+			Where("amenities.uuid::text IN ? OR lower(amenities.name) IN ?", amenities, amenities).
+			Group("room_amenities.room_id").
+			Having("COUNT(DISTINCT amenities.id) = ?", len(amenities))
+		query = query.Where(`"Room".id IN (?)`, rooms)
+	}
+
+	err := query.
+		Order(`"Room".name asc`).
+		Order(`"Room".id asc`).
+		Order("room_schedules.date asc").
+		Order(`"Time".start_time asc`).
+		Find(&roomSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return roomSchedules, nil
+}
+
 func (f *RoomScheduleRepository) FindByUUID(ctx context.Context, uuid string) (*models.RoomSchedule, error) {
 	var roomSchedules models.RoomSchedule
 	err := f.db.
@@ -112,6 +195,75 @@ func (f *RoomScheduleRepository) FindByUUID(ctx context.Context, uuid string) (*
 	return &roomSchedules, nil
 }
 
+func (f *RoomScheduleRepository) FindByIDForUpdate(ctx context.Context, tx *gorm.DB, id uint) (*models.RoomSchedule, error) {
+	var roomSchedule models.RoomSchedule
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Room").
+		Preload("Time").
+		Where("id = ?", id).
+		First(&roomSchedule).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errRoomSchedule.ErrRoomScheduleNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &roomSchedule, nil
+}
+
+// FindAllByUUIDsForUpdate locks the given schedules until tx ends. Rows are
+// locked in id order so that overlapping bookings cannot deadlock.
+func (f *RoomScheduleRepository) FindAllByUUIDsForUpdate(ctx context.Context, tx *gorm.DB, uuids []string) ([]models.RoomSchedule, error) {
+	var roomSchedules []models.RoomSchedule
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Room").
+		Preload("Time").
+		Where("uuid IN ?", uuids).
+		Order("id asc").
+		Find(&roomSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return roomSchedules, nil
+}
+
+// FindAllByRoomIDAndTimeRangeForUpdate locks the schedules of the room on the
+// date whose time slot lies within startTime and endTime, ordered by start
+// time.
+func (f *RoomScheduleRepository) FindAllByRoomIDAndTimeRangeForUpdate(
+	ctx context.Context,
+	tx *gorm.DB,
+	roomID uint,
+	date, startTime, endTime string,
+) ([]models.RoomSchedule, error) {
+	var roomSchedules []models.RoomSchedule
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "room_schedules"}}).
+		Preload("Room").
+		Preload("Time").
+		Joins("JOIN times ON times.id = room_schedules.time_id").
+		Where("room_schedules.room_id = ?", roomID).
+		Where("room_schedules.date = ?", date).
+		Where("times.start_time >= ? AND times.end_time <= ?", startTime, endTime).
+		Order("times.start_time asc").
+		Find(&roomSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return roomSchedules, nil
+}
+
 func (f *RoomScheduleRepository) FindByDateAndTimeID(ctx context.Context, date string, timeID int, roomID int) (*models.RoomSchedule, error) {
 	var roomSchedules models.RoomSchedule
 	err := f.db.
@@ -123,7 +275,7 @@ func (f *RoomScheduleRepository) FindByDateAndTimeID(ctx context.Context, date s
 		Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errWrap.WrapError(errRoomSchedule.ErrRoomScheduleNotFound)
+			return nil, nil
 		}
 		return nil, errWrap.WrapError(errConstant.ErrSQLError)
 	}
@@ -131,45 +283,199 @@ func (f *RoomScheduleRepository) FindByDateAndTimeID(ctx context.Context, date s
 	return &roomSchedules, nil
 }
 
-func (f *RoomScheduleRepository) Create(ctx context.Context, req []models.RoomSchedule) error {
-	err := f.db.WithContext(ctx).Create(&req).Error
+func (f *RoomScheduleRepository) FindAllByRoomIDAndDateRange(ctx context.Context, roomID uint, startDate, endDate time.Time) ([]models.RoomSchedule, error) {
+	var roomSchedules []models.RoomSchedule
+	err := f.db.
+		WithContext(ctx).
+		Where("room_id = ?", roomID).
+		Where("date BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)).
+		Find(&roomSchedules).
+		Error
 	if err != nil {
-		return errWrap.WrapError(errConstant.ErrSQLError)
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
-	return nil
+	return roomSchedules, nil
 }
 
-func (f *RoomScheduleRepository) Update(ctx context.Context, uuid string, req *models.RoomSchedule) (*models.RoomSchedule, error) {
-	roomSchedule, err := f.FindByUUID(ctx, uuid)
+// FindAllByDateRangeForUpdate locks the schedules between the two dates of
+// one room, or of every room of the library when roomID is nil.
+func (f *RoomScheduleRepository) FindAllByDateRangeForUpdate(
+	ctx context.Context,
+	tx *gorm.DB,
+	library string,
+	roomID *uint,
+	startDate, endDate time.Time,
+) ([]models.RoomSchedule, error) {
+	var roomSchedules []models.RoomSchedule
+	query := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Scopes(f.scopeLibrary(library)).
+		Where("date BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly))
+	if roomID != nil {
+		query = query.Where("room_id = ?", *roomID)
+	}
+
+	err := query.Order("id asc").Find(&roomSchedules).Error
 	if err != nil {
-		return nil, err
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
-	roomSchedule.Date = req.Date
-	err = f.db.WithContext(ctx).Save(&roomSchedule).Error
+	return roomSchedules, nil
+}
+
+// FindAllByTimeIDForUpdate locks every schedule on the time slot until tx
+// ends, in id order.
+func (f *RoomScheduleRepository) FindAllByTimeIDForUpdate(ctx context.Context, tx *gorm.DB, timeID uint) ([]models.RoomSchedule, error) {
+	var roomSchedules []models.RoomSchedule
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("time_id = ?", timeID).
+		Order("id asc").
+		Find(&roomSchedules).
+		Error
 	if err != nil {
 		return nil, errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
-	return roomSchedule, nil
+	return roomSchedules, nil
+}
+
+// CountByTimeID counts the schedules using the time slot, limited to the
+// given statuses when any are passed.
+func (f *RoomScheduleRepository) CountByTimeID(ctx context.Context, timeID uint, statuses []constans.RoomScheduleStatus) (int64, error) {
+	var count int64
+	query := f.db.WithContext(ctx).Model(&models.RoomSchedule{}).Where("time_id = ?", timeID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return count, nil
 }
 
-func (f *RoomScheduleRepository) UpdateStatus(ctx context.Context, status constans.RoomScheduleStatus, uuid string) error {
-	roomSchedule, err := f.FindByUUID(ctx, uuid)
+func (f *RoomScheduleRepository) Create(ctx context.Context, req []models.RoomSchedule) error {
+	err := f.db.WithContext(ctx).CreateInBatches(&req, 500).Error
 	if err != nil {
-		return err
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errWrap.WrapError(errRoomSchedule.ErrRoomScheduleIsExist)
+		}
+		return errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
-	roomSchedule.Status = status
-	err = f.db.WithContext(ctx).Save(&roomSchedule).Error
+	return nil
+}
+
+func (f *RoomScheduleRepository) Update(ctx context.Context, tx *gorm.DB, uuid string, req *models.RoomSchedule) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.RoomSchedule{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{
+			"date":    req.Date,
+			"time_id": req.TimeID,
+		}).
+		Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errWrap.WrapError(errRoomSchedule.ErrRoomScheduleIsExist)
+		}
 		return errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
 	return nil
 }
 
+// UpdateStatus also clears any hold on the schedule, since only Hold puts a
+// schedule in the Held status.
+func (f *RoomScheduleRepository) UpdateStatus(ctx context.Context, tx *gorm.DB, status constans.RoomScheduleStatus, uuid string) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.RoomSchedule{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{
+			"status":     status,
+			"held_by":    nil,
+			"held_until": nil,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// Hold reserves the schedule for the user until heldUntil.
+func (f *RoomScheduleRepository) Hold(ctx context.Context, tx *gorm.DB, uuid string, heldBy uuid.UUID, heldUntil time.Time) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.RoomSchedule{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{
+			"status":     constans.Held,
+			"held_by":    heldBy,
+			"held_until": heldUntil,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (f *RoomScheduleRepository) CountHeldByUserID(ctx context.Context, tx *gorm.DB, userID uuid.UUID, now time.Time) (int64, error) {
+	var count int64
+	err := tx.
+		WithContext(ctx).
+		Model(&models.RoomSchedule{}).
+		Where("status = ?", constans.Held).
+		Where("held_by = ?", userID).
+		Where("held_until > ?", now).
+		Count(&count).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return count, nil
+}
+
+// FindAllExpiredHoldsForUpdate locks the held schedules whose hold has run
+// out. Holds backing a waitlist offer are left to the waitlist, and rows
+// locked by another transaction are skipped.
+func (f *RoomScheduleRepository) FindAllExpiredHoldsForUpdate(ctx context.Context, tx *gorm.DB, now time.Time) ([]models.RoomSchedule, error) {
+	var roomSchedules []models.RoomSchedule
+	offers := tx.
+		Model(&models.WaitlistEntry{}).
+		Select("1").
+		Where("waitlist_entries.room_schedule_id = room_schedules.id").
+		Where("waitlist_entries.user_id = room_schedules.held_by").
+		Where("waitlist_entries.status = ?", constans.WaitlistOffered)
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Preload("Time").
+		Where("status = ?", constans.Held).
+		Where("held_until <= ?", now).
+		Where("NOT EXISTS (?)", offers).
+		Order("id asc").
+		Find(&roomSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return roomSchedules, nil
+}
+
 func (f *RoomScheduleRepository) Delete(ctx context.Context, uuid string) error {
 	err := f.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.RoomSchedule{}).Error
 	if err != nil {
@@ -177,3 +483,11 @@ func (f *RoomScheduleRepository) Delete(ctx context.Context, uuid string) error
 	}
 	return nil
 }
+
+func (f *RoomScheduleRepository) DeleteByTimeID(ctx context.Context, tx *gorm.DB, timeID uint) error {
+	err := tx.WithContext(ctx).Where("time_id = ?", timeID).Delete(&models.RoomSchedule{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
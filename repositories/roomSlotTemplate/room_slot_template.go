@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	errWrap "room-service/common/error"
+	errConstant "room-service/constants/error"
+	"room-service/domain/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RoomSlotTemplateRepository struct {
+	db *gorm.DB
+}
+
+type IRoomSlotTemplateRepository interface {
+	FindAllByRoomID(context.Context, uint) (models.RoomSlotTemplates, error)
+	Replace(context.Context, *gorm.DB, uint, models.RoomSlotTemplates) error
+}
+
+func NewRoomSlotTemplateRepository(db *gorm.DB) IRoomSlotTemplateRepository {
+	return &RoomSlotTemplateRepository{db: db}
+}
+
+func (r *RoomSlotTemplateRepository) FindAllByRoomID(ctx context.Context, roomID uint) (models.RoomSlotTemplates, error) {
+	var templates models.RoomSlotTemplates
+	err := r.db.
+		WithContext(ctx).
+		Preload("Time").
+		Joins("JOIN times ON times.id = room_slot_templates.time_id").
+		Where("room_slot_templates.room_id = ?", roomID).
+		Order("room_slot_templates.weekday asc nulls first").
+		Order("times.start_time asc").
+		Find(&templates).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return templates, nil
+}
+
+// Replace swaps the whole template of the room for the given one.
+func (r *RoomSlotTemplateRepository) Replace(ctx context.Context, tx *gorm.DB, roomID uint, templates models.RoomSlotTemplates) error {
+	err := tx.WithContext(ctx).Where("room_id = ?", roomID).Delete(&models.RoomSlotTemplate{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if len(templates) == 0 {
+		return nil
+	}
+
+	for i := range templates {
+		templates[i].UUID = uuid.New()
+		templates[i].RoomID = roomID
+	}
+
+	err = tx.WithContext(ctx).Create(&templates).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TimeRepository struct {
@@ -17,19 +18,32 @@ type TimeRepository struct {
 }
 
 type ITimeRepository interface {
-	FindAll(context.Context) ([]models.Time, error)
+	FindAll(context.Context, string) ([]models.Time, error)
 	FindByUUID(context.Context, string) (*models.Time, error)
+	FindByUUIDForUpdate(context.Context, *gorm.DB, string) (*models.Time, error)
 	FindByID(context.Context, int) (*models.Time, error)
+	FindOverlapping(context.Context, string, string, string, uint) ([]models.Time, error)
 	Create(context.Context, *models.Time) (*models.Time, error)
+	Update(context.Context, string, *models.Time) (*models.Time, error)
+	Delete(context.Context, *gorm.DB, string) error
 }
 
 func NewTimeRepository(db *gorm.DB) ITimeRepository {
 	return &TimeRepository{db: db}
 }
 
-func (t *TimeRepository) FindAll(ctx context.Context) ([]models.Time, error) {
+func (t *TimeRepository) scopeLibrary(library string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if library == "" {
+			return db
+		}
+		return db.Where("library = ?", library)
+	}
+}
+
+func (t *TimeRepository) FindAll(ctx context.Context, library string) ([]models.Time, error) {
 	var times []models.Time
-	err := t.db.WithContext(ctx).Find(&times).Error
+	err := t.db.WithContext(ctx).Scopes(t.scopeLibrary(library)).Order("start_time asc").Find(&times).Error
 	if err != nil {
 		return nil, errWrap.WrapError(errConstant.ErrSQLError)
 	}
@@ -48,6 +62,20 @@ func (t *TimeRepository) FindByUUID(ctx context.Context, uuid string) (*models.T
 	return &time, nil
 }
 
+// FindByUUIDForUpdate locks the time slot until tx ends, which also keeps
+// new room schedules from being created on it.
+func (t *TimeRepository) FindByUUIDForUpdate(ctx context.Context, tx *gorm.DB, uuid string) (*models.Time, error) {
+	var time models.Time
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", uuid).First(&time).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errTime.ErrTimeNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &time, nil
+}
+
 func (t *TimeRepository) FindByID(ctx context.Context, id int) (*models.Time, error) {
 	var time models.Time
 	err := t.db.WithContext(ctx).Where("id = ?", id).First(&time).Error
@@ -60,6 +88,23 @@ func (t *TimeRepository) FindByID(ctx context.Context, id int) (*models.Time, er
 	return &time, nil
 }
 
+// FindOverlapping returns the time slots of the library that overlap the
+// given range, ignoring the slot with excludeID.
+func (t *TimeRepository) FindOverlapping(ctx context.Context, library, startTime, endTime string, excludeID uint) ([]models.Time, error) {
+	var times []models.Time
+	err := t.db.
+		WithContext(ctx).
+		Scopes(t.scopeLibrary(library)).
+		Where("start_time < ? AND end_time > ?", endTime, startTime).
+		Where("id <> ?", excludeID).
+		Find(&times).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return times, nil
+}
+
 func (t *TimeRepository) Create(ctx context.Context, time *models.Time) (*models.Time, error) {
 	time.UUID = uuid.New()
 	err := t.db.WithContext(ctx).Create(&time).Error
@@ -68,3 +113,29 @@ func (t *TimeRepository) Create(ctx context.Context, time *models.Time) (*models
 	}
 	return time, nil
 }
+
+func (t *TimeRepository) Update(ctx context.Context, uuid string, req *models.Time) (*models.Time, error) {
+	var time models.Time
+	err := t.db.
+		WithContext(ctx).
+		Model(&time).
+		Clauses(clause.Returning{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]interface{}{
+			"start_time": req.StartTime,
+			"end_time":   req.EndTime,
+		}).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &time, nil
+}
+
+func (t *TimeRepository) Delete(ctx context.Context, tx *gorm.DB, uuid string) error {
+	err := tx.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.Time{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
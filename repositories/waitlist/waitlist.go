@@ -0,0 +1,217 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "room-service/common/error"
+	"room-service/constants"
+	errConstant "room-service/constants/error"
+	errWaitlist "room-service/constants/error/waitlist"
+	"room-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WaitlistRepository struct {
+	db *gorm.DB
+}
+
+type IWaitlistRepository interface {
+	FindAllByUserID(context.Context, uuid.UUID) ([]models.WaitlistEntry, error)
+	FindAllActiveByRoomScheduleID(context.Context, uint) ([]models.WaitlistEntry, error)
+	FindAllExpiredOffers(context.Context, time.Time) ([]models.WaitlistEntry, error)
+	FindByUUID(context.Context, string) (*models.WaitlistEntry, error)
+	FindByUUIDForUpdate(context.Context, *gorm.DB, string) (*models.WaitlistEntry, error)
+	FindNextWaitingForUpdate(context.Context, *gorm.DB, uint) (*models.WaitlistEntry, error)
+	CountWaitingAhead(context.Context, *models.WaitlistEntry) (int64, error)
+	Create(context.Context, *models.WaitlistEntry) (*models.WaitlistEntry, error)
+	UpdateStatus(context.Context, *gorm.DB, string, constants.WaitlistStatus) error
+	Offer(context.Context, *gorm.DB, string, time.Time, time.Time) error
+}
+
+func NewWaitlistRepository(db *gorm.DB) IWaitlistRepository {
+	return &WaitlistRepository{db: db}
+}
+
+func (w *WaitlistRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("RoomSchedule.Room").
+		Preload("RoomSchedule.Time")
+}
+
+func (w *WaitlistRepository) FindAllByUserID(ctx context.Context, userID uuid.UUID) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	err := w.db.
+		WithContext(ctx).
+		Scopes(w.preload).
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Find(&entries).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return entries, nil
+}
+
+// FindAllActiveByRoomScheduleID returns the queue of the schedule in the
+// order users joined it.
+func (w *WaitlistRepository) FindAllActiveByRoomScheduleID(ctx context.Context, roomScheduleID uint) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	err := w.db.
+		WithContext(ctx).
+		Scopes(w.preload).
+		Where("room_schedule_id = ?", roomScheduleID).
+		Where("status IN ?", []constants.WaitlistStatus{constants.WaitlistWaiting, constants.WaitlistOffered}).
+		Order("id asc").
+		Find(&entries).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return entries, nil
+}
+
+func (w *WaitlistRepository) FindAllExpiredOffers(ctx context.Context, now time.Time) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	err := w.db.
+		WithContext(ctx).
+		Where("status = ?", constants.WaitlistOffered).
+		Where("offer_expires_at <= ?", now).
+		Order("offer_expires_at asc").
+		Find(&entries).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return entries, nil
+}
+
+func (w *WaitlistRepository) FindByUUID(ctx context.Context, uuid string) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := w.db.
+		WithContext(ctx).
+		Scopes(w.preload).
+		Where("uuid = ?", uuid).
+		First(&entry).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errWaitlist.ErrWaitlistNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &entry, nil
+}
+
+func (w *WaitlistRepository) FindByUUIDForUpdate(ctx context.Context, tx *gorm.DB, uuid string) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uuid = ?", uuid).
+		First(&entry).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errWaitlist.ErrWaitlistNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &entry, nil
+}
+
+// FindNextWaitingForUpdate locks the first entry still waiting for the
+// schedule, returning nil when the queue is empty. Entries locked by another
+// transaction are skipped rather than waited for.
+func (w *WaitlistRepository) FindNextWaitingForUpdate(ctx context.Context, tx *gorm.DB, roomScheduleID uint) (*models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("room_schedule_id = ?", roomScheduleID).
+		Where("status = ?", constants.WaitlistWaiting).
+		Order("id asc").
+		Limit(1).
+		Find(&entries).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	return &entries[0], nil
+}
+
+func (w *WaitlistRepository) CountWaitingAhead(ctx context.Context, entry *models.WaitlistEntry) (int64, error) {
+	var count int64
+	err := w.db.
+		WithContext(ctx).
+		Model(&models.WaitlistEntry{}).
+		Where("room_schedule_id = ?", entry.RoomScheduleID).
+		Where("status = ?", constants.WaitlistWaiting).
+		Where("id < ?", entry.ID).
+		Count(&count).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return count, nil
+}
+
+func (w *WaitlistRepository) Create(ctx context.Context, req *models.WaitlistEntry) (*models.WaitlistEntry, error) {
+	req.UUID = uuid.New()
+	err := w.db.WithContext(ctx).Create(req).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errWrap.WrapError(errWaitlist.ErrWaitlistAlreadyJoined)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return req, nil
+}
+
+func (w *WaitlistRepository) UpdateStatus(ctx context.Context, tx *gorm.DB, uuid string, status constants.WaitlistStatus) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.WaitlistEntry{}).
+		Where("uuid = ?", uuid).
+		Update("status", status).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (w *WaitlistRepository) Offer(ctx context.Context, tx *gorm.DB, uuid string, offeredAt, expiresAt time.Time) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.WaitlistEntry{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{
+			"status":           constants.WaitlistOffered,
+			"offered_at":       offeredAt,
+			"offer_expires_at": expiresAt,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"room-service/clients"
+	"room-service/constants"
+	"room-service/controllers"
+	"room-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AmenityRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IAmenityRoute interface {
+	Run()
+}
+
+func NewAmenityRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IAmenityRoute {
+	return &AmenityRoute{controller: controller, group: group, client: client}
+}
+
+func (a *AmenityRoute) Run() {
+	group := a.group.Group("/amenity")
+	group.GET("", middlewares.AuthenticateWithoutToken(), a.controller.GetAmenity().GetAll)
+	group.GET("/:uuid", middlewares.AuthenticateWithoutToken(), a.controller.GetAmenity().GetByUUID)
+	group.Use(middlewares.Authenticate())
+	group.POST("", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+	}, a.client),
+		a.controller.GetAmenity().Create)
+
+	group.PUT("/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+	}, a.client),
+		a.controller.GetAmenity().Update)
+
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+	}, a.client),
+		a.controller.GetAmenity().Delete)
+}
@@ -0,0 +1,143 @@
+package routes
+
+import (
+	"room-service/clients"
+	"room-service/constants"
+	"room-service/controllers"
+	"room-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookingRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IBookingRoute interface {
+	Run()
+}
+
+func NewBookingRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IBookingRoute {
+	return &BookingRoute{controller: controller, group: group, client: client}
+}
+
+func (b *BookingRoute) Run() {
+	group := b.group.Group("/booking")
+	// The room's QR code is scanned from any device, so the check-in token
+	// stands in for the API key and user token.
+	group.POST("/check-in/room/:uuid", b.controller.GetBooking().CheckInByRoom)
+	group.Use(middlewares.Authenticate())
+	group.GET("/pagination", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+	}, b.client),
+		b.controller.GetBooking().GetAllWithPagination)
+
+	group.GET("/approval", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+	}, b.client),
+		b.controller.GetBooking().GetAllPending)
+
+	group.PATCH("/approval", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+	}, b.client),
+		b.controller.GetBooking().Review)
+
+	group.GET("/me", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, b.client),
+		b.controller.GetBooking().GetAllByUser)
+
+	group.GET("/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, b.client),
+		b.controller.GetBooking().GetByUUID)
+
+	group.POST("", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, b.client),
+		b.controller.GetBooking().Create)
+
+	group.PATCH("/:uuid/cancel", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, b.client),
+		b.controller.GetBooking().Cancel)
+
+	group.PATCH("/:uuid/cancel/admin", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+	}, b.client),
+		b.controller.GetBooking().CancelByAdmin)
+
+	group.PATCH("/:uuid/check-in", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, b.client),
+		b.controller.GetBooking().CheckIn)
+
+	group.GET("/series/me", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+	}, b.client),
+		b.controller.GetBooking().GetAllSeriesByUser)
+
+	group.GET("/series/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+	}, b.client),
+		b.controller.GetBooking().GetSeriesByUUID)
+
+	group.POST("/series", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+	}, b.client),
+		b.controller.GetBooking().CreateSeries)
+
+	group.PATCH("/series/:uuid/cancel", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+	}, b.client),
+		b.controller.GetBooking().CancelSeries)
+
+	group.PATCH("/series/:uuid/cancel/admin", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+	}, b.client),
+		b.controller.GetBooking().CancelSeriesByAdmin)
+}
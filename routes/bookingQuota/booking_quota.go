@@ -0,0 +1,53 @@
+package routes
+
+import (
+	"room-service/clients"
+	"room-service/constants"
+	"room-service/controllers"
+	"room-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookingQuotaRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IBookingQuotaRoute interface {
+	Run()
+}
+
+func NewBookingQuotaRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IBookingQuotaRoute {
+	return &BookingQuotaRoute{controller: controller, group: group, client: client}
+}
+
+func (b *BookingQuotaRoute) Run() {
+	group := b.group.Group("/booking/quota")
+	group.Use(middlewares.Authenticate())
+	group.GET("", middlewares.CheckRole([]string{
+		constants.Administrator,
+	}, b.client),
+		b.controller.GetBookingQuota().GetAll)
+
+	group.GET("/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+	}, b.client),
+		b.controller.GetBookingQuota().GetByUUID)
+
+	group.POST("", middlewares.CheckRole([]string{
+		constants.Administrator,
+	}, b.client),
+		b.controller.GetBookingQuota().Create)
+
+	group.PUT("/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+	}, b.client),
+		b.controller.GetBookingQuota().Update)
+
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+	}, b.client),
+		b.controller.GetBookingQuota().Delete)
+}
@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"room-service/clients"
+	"room-service/constants"
+	"room-service/controllers"
+	"room-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarFeedRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type ICalendarFeedRoute interface {
+	Run()
+}
+
+func NewCalendarFeedRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) ICalendarFeedRoute {
+	return &CalendarFeedRoute{controller: controller, group: group, client: client}
+}
+
+func (f *CalendarFeedRoute) Run() {
+	group := f.group.Group("/calendar/feed")
+	// Calendar apps cannot send the API key headers, so the feed itself is
+	// authorized by the signed token in its URL.
+	group.GET("/:uuid", f.controller.GetCalendarFeed().Render)
+	group.Use(middlewares.Authenticate())
+	group.GET("", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, f.client),
+		f.controller.GetCalendarFeed().GetAllByUser)
+
+	group.POST("", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, f.client),
+		f.controller.GetCalendarFeed().Create)
+
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, f.client),
+		f.controller.GetCalendarFeed().Revoke)
+}
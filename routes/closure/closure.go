@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"room-service/clients"
+	"room-service/constants"
+	"room-service/controllers"
+	"room-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ClosureRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IClosureRoute interface {
+	Run()
+}
+
+func NewClosureRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IClosureRoute {
+	return &ClosureRoute{controller: controller, group: group, client: client}
+}
+
+func (cl *ClosureRoute) Run() {
+	group := cl.group.Group("/closure")
+	group.Use(middlewares.Authenticate())
+	group.GET("", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+	}, cl.client),
+		cl.controller.GetClosure().GetAll)
+
+	group.GET("/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+	}, cl.client),
+		cl.controller.GetClosure().GetByUUID)
+
+	group.POST("", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+	}, cl.client),
+		cl.controller.GetClosure().Create)
+
+	group.PUT("/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+	}, cl.client),
+		cl.controller.GetClosure().Update)
+
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+	}, cl.client),
+		cl.controller.GetClosure().Delete)
+}
@@ -0,0 +1,61 @@
+package routes
+
+import (
+	"room-service/clients"
+	"room-service/constants"
+	"room-service/controllers"
+	"room-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PenaltyRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IPenaltyRoute interface {
+	Run()
+}
+
+func NewPenaltyRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IPenaltyRoute {
+	return &PenaltyRoute{controller: controller, group: group, client: client}
+}
+
+func (p *PenaltyRoute) Run() {
+	group := p.group.Group("/penalty")
+	group.Use(middlewares.Authenticate())
+	group.GET("/me", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, p.client),
+		p.controller.GetPenalty().GetMine)
+
+	group.GET("/strike", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+	}, p.client),
+		p.controller.GetPenalty().GetStrikes)
+
+	group.PATCH("/strike/:uuid/clear", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+	}, p.client),
+		p.controller.GetPenalty().ClearStrike)
+
+	group.GET("/suspension", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+	}, p.client),
+		p.controller.GetPenalty().GetSuspensions)
+
+	group.PATCH("/suspension/:uuid/lift", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+	}, p.client),
+		p.controller.GetPenalty().LiftSuspension)
+}
@@ -3,9 +3,17 @@ package routes
 import (
 	"room-service/clients"
 	"room-service/controllers"
+	amenityRoute "room-service/routes/amenity"
+	bookingRoute "room-service/routes/booking"
+	bookingQuotaRoute "room-service/routes/bookingQuota"
+	calendarFeedRoute "room-service/routes/calendarFeed"
+	closureRoute "room-service/routes/closure"
+	penaltyRoute "room-service/routes/penalty"
 	routes "room-service/routes/room"
 	routes2 "room-service/routes/roomSchedule"
+	roomSlotTemplateRoute "room-service/routes/roomSlotTemplate"
 	timeRoute "room-service/routes/time"
+	waitlistRoute "room-service/routes/waitlist"
 
 	"github.com/gin-gonic/gin"
 )
@@ -24,6 +32,30 @@ func NewRouteRegistry(controller controllers.IControllerRegistry, group *gin.Rou
 	return &Registry{controller: controller, group: group, client: client}
 }
 
+func (r *Registry) amenityRoute() amenityRoute.IAmenityRoute {
+	return amenityRoute.NewAmenityRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) bookingRoute() bookingRoute.IBookingRoute {
+	return bookingRoute.NewBookingRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) bookingQuotaRoute() bookingQuotaRoute.IBookingQuotaRoute {
+	return bookingQuotaRoute.NewBookingQuotaRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) calendarFeedRoute() calendarFeedRoute.ICalendarFeedRoute {
+	return calendarFeedRoute.NewCalendarFeedRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) closureRoute() closureRoute.IClosureRoute {
+	return closureRoute.NewClosureRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) penaltyRoute() penaltyRoute.IPenaltyRoute {
+	return penaltyRoute.NewPenaltyRoute(r.controller, r.group, r.client)
+}
+
 func (r *Registry) roomRoute() routes.IRoomRoute {
 	return routes.NewRoomRoute(r.controller, r.group, r.client)
 }
@@ -32,12 +64,28 @@ func (r *Registry) roomScheduleRoute() routes2.IRoomScheduleRoute {
 	return routes2.NewRoomScheduleRoute(r.controller, r.group, r.client)
 }
 
+func (r *Registry) roomSlotTemplateRoute() roomSlotTemplateRoute.IRoomSlotTemplateRoute {
+	return roomSlotTemplateRoute.NewRoomSlotTemplateRoute(r.controller, r.group, r.client)
+}
+
 func (r *Registry) timeRoute() timeRoute.ITimeRoute {
 	return timeRoute.NewTimeRoute(r.controller, r.group, r.client)
 }
 
+func (r *Registry) waitlistRoute() waitlistRoute.IWaitlistRoute {
+	return waitlistRoute.NewWaitlistRoute(r.controller, r.group, r.client)
+}
+
 func (r *Registry) Serve() {
 	r.roomRoute().Run()
 	r.roomScheduleRoute().Run()
+	r.roomSlotTemplateRoute().Run()
 	r.timeRoute().Run()
+	r.bookingRoute().Run()
+	r.bookingQuotaRoute().Run()
+	r.closureRoute().Run()
+	r.amenityRoute().Run()
+	r.calendarFeedRoute().Run()
+	r.waitlistRoute().Run()
+	r.penaltyRoute().Run()
 }
@@ -25,8 +25,8 @@ func NewRoomRoute(controller controllers.IControllerRegistry, group *gin.RouterG
 
 func (r *RoomRoute) Run() {
 	group := r.group.Group("/room")
-	group.GET("", middlewares.AuthenticateWithoutToken(), r.controller.GetRoom().GetAllWithoutPagination)
-	group.GET("/:uuid", middlewares.AuthenticateWithoutToken(), r.controller.GetRoom().GetByUUID)
+	group.GET("", middlewares.AuthenticateWithoutToken(), middlewares.SetLibrary(), r.controller.GetRoom().GetAllWithoutPagination)
+	group.GET("/:uuid", middlewares.AuthenticateWithoutToken(), middlewares.SetLibrary(), r.controller.GetRoom().GetByUUID)
 	group.Use(middlewares.Authenticate())
 	group.GET("/pagination", middlewares.CheckRole([]string{
 		constants.Administrator,
@@ -57,4 +57,30 @@ func (r *RoomRoute) Run() {
 		constants.Staff,
 	}, r.client),
 		r.controller.GetRoom().Delete)
+
+	group.GET("/:uuid/check-in-token", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+	}, r.client),
+		r.controller.GetRoom().GetCheckInToken)
+
+	group.PATCH("/:uuid/check-in-token", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+	}, r.client),
+		r.controller.GetRoom().RotateCheckInToken)
+
+	group.POST("/import", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+	}, r.client),
+		r.controller.GetRoom().Import)
+
+	group.GET("/export", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+	}, r.client),
+		r.controller.GetRoom().Export)
 }
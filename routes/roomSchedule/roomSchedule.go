@@ -25,8 +25,9 @@ func NewRoomScheduleRoute(controller controllers.IControllerRegistry, group *gin
 
 func (r *RoomScheduleRoute) Run() {
 	group := r.group.Group("/room/schedule")
-	group.GET("", middlewares.AuthenticateWithoutToken(), r.controller.GetRoomSchedule().GetAllByRoomIDAndDate)
-	group.PATCH("", middlewares.AuthenticateWithoutToken(), r.controller.GetRoomSchedule().UpdateStatus)
+	group.GET("", middlewares.AuthenticateWithoutToken(), middlewares.SetLibrary(), r.controller.GetRoomSchedule().GetAllByRoomIDAndDate)
+	group.GET("/availability", middlewares.AuthenticateWithoutToken(), middlewares.SetLibrary(), r.controller.GetRoomSchedule().SearchAvailability)
+	group.GET("/calendar", middlewares.AuthenticateWithoutToken(), middlewares.SetLibrary(), r.controller.GetRoomSchedule().GetCalendar)
 	group.Use(middlewares.Authenticate())
 	group.GET("/pagination", middlewares.CheckRole([]string{
 		constants.Administrator,
@@ -60,6 +61,31 @@ func (r *RoomScheduleRoute) Run() {
 	}, r.client),
 		r.controller.GetRoomSchedule().GenerateScheduleForOneMonth)
 
+	group.POST("/generate", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+	}, r.client),
+		r.controller.GetRoomSchedule().GenerateSchedule)
+
+	group.POST("/hold", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, r.client),
+		r.controller.GetRoomSchedule().Hold)
+
+	group.POST("/hold/release", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, r.client),
+		r.controller.GetRoomSchedule().Release)
+
 	group.PUT("/:uuid", middlewares.CheckRole([]string{
 		constants.Administrator,
 		constants.Co_Administrator,
@@ -67,6 +93,13 @@ func (r *RoomScheduleRoute) Run() {
 	}, r.client),
 		r.controller.GetRoomSchedule().Update)
 
+	group.PATCH("/:uuid/status", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+	}, r.client),
+		r.controller.GetRoomSchedule().UpdateStatus)
+
 	group.DELETE("/:uuid", middlewares.CheckRole([]string{
 		constants.Administrator,
 		constants.Co_Administrator,
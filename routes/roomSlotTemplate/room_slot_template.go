@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"room-service/clients"
+	"room-service/constants"
+	"room-service/controllers"
+	"room-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoomSlotTemplateRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IRoomSlotTemplateRoute interface {
+	Run()
+}
+
+func NewRoomSlotTemplateRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IRoomSlotTemplateRoute {
+	return &RoomSlotTemplateRoute{controller: controller, group: group, client: client}
+}
+
+func (r *RoomSlotTemplateRoute) Run() {
+	group := r.group.Group("/room/template")
+	group.Use(middlewares.Authenticate())
+	group.GET("/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, r.client),
+		r.controller.GetRoomSlotTemplate().GetByRoomID)
+
+	group.PUT("/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+	}, r.client),
+		r.controller.GetRoomSlotTemplate().Replace)
+}
@@ -46,4 +46,18 @@ func (t *TimeRoute) Run() {
 		constants.Staff,
 	}, t.client),
 		t.controller.GetTime().Create)
+
+	group.PUT("/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+	}, t.client),
+		t.controller.GetTime().Update)
+
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+	}, t.client),
+		t.controller.GetTime().Delete)
 }
@@ -0,0 +1,71 @@
+package routes
+
+import (
+	"room-service/clients"
+	"room-service/constants"
+	"room-service/controllers"
+	"room-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WaitlistRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IWaitlistRoute interface {
+	Run()
+}
+
+func NewWaitlistRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IWaitlistRoute {
+	return &WaitlistRoute{controller: controller, group: group, client: client}
+}
+
+func (w *WaitlistRoute) Run() {
+	group := w.group.Group("/waitlist")
+	group.Use(middlewares.Authenticate())
+	group.GET("/me", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, w.client),
+		w.controller.GetWaitlist().GetAllByUser)
+
+	group.GET("/schedule/:uuid", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+	}, w.client),
+		w.controller.GetWaitlist().GetAllByRoomSchedule)
+
+	group.POST("", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, w.client),
+		w.controller.GetWaitlist().Join)
+
+	group.PATCH("/:uuid/accept", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, w.client),
+		w.controller.GetWaitlist().Accept)
+
+	group.PATCH("/:uuid/cancel", middlewares.CheckRole([]string{
+		constants.Administrator,
+		constants.Co_Administrator,
+		constants.Staff,
+		constants.Lecture,
+		constants.Student,
+	}, w.client),
+		w.controller.GetWaitlist().Cancel)
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"room-service/domain/dto"
+	"room-service/domain/models"
+	"room-service/repositories"
+)
+
+type AmenityService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IAmenityService interface {
+	GetAll(context.Context) ([]dto.AmenityResponse, error)
+	GetByUUID(context.Context, string) (*dto.AmenityResponse, error)
+	Create(context.Context, *dto.AmenityRequest) (*dto.AmenityResponse, error)
+	Update(context.Context, string, *dto.AmenityRequest) (*dto.AmenityResponse, error)
+	Delete(context.Context, string) error
+}
+
+func NewAmenityService(repository repositories.IRepositoryRegistry) IAmenityService {
+	return &AmenityService{repository: repository}
+}
+
+func (a *AmenityService) toAmenityResponse(amenity *models.Amenity) dto.AmenityResponse {
+	return dto.AmenityResponse{
+		UUID:        amenity.UUID,
+		Name:        amenity.Name,
+		Description: amenity.Description,
+		CreatedAt:   amenity.CreatedAt,
+		UpdatedAt:   amenity.UpdatedAt,
+	}
+}
+
+func (a *AmenityService) GetAll(ctx context.Context) ([]dto.AmenityResponse, error) {
+	amenities, err := a.repository.GetAmenity().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	amenityResults := make([]dto.AmenityResponse, 0, len(amenities))
+	for _, amenity := range amenities {
+		amenityResults = append(amenityResults, a.toAmenityResponse(&amenity))
+	}
+
+	return amenityResults, nil
+}
+
+func (a *AmenityService) GetByUUID(ctx context.Context, uuid string) (*dto.AmenityResponse, error) {
+	amenity, err := a.repository.GetAmenity().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := a.toAmenityResponse(amenity)
+	return &response, nil
+}
+
+func (a *AmenityService) Create(ctx context.Context, request *dto.AmenityRequest) (*dto.AmenityResponse, error) {
+	amenity, err := a.repository.GetAmenity().Create(ctx, &models.Amenity{
+		Name:        request.Name,
+		Description: request.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := a.toAmenityResponse(amenity)
+	return &response, nil
+}
+
+func (a *AmenityService) Update(ctx context.Context, uuid string, request *dto.AmenityRequest) (*dto.AmenityResponse, error) {
+	_, err := a.repository.GetAmenity().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	amenity, err := a.repository.GetAmenity().Update(ctx, uuid, &models.Amenity{
+		Name:        request.Name,
+		Description: request.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := a.toAmenityResponse(amenity)
+	return &response, nil
+}
+
+func (a *AmenityService) Delete(ctx context.Context, uuid string) error {
+	_, err := a.repository.GetAmenity().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return a.repository.GetAmenity().Delete(ctx, uuid)
+}
@@ -0,0 +1,1284 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	clients "room-service/clients/user"
+	"room-service/common/rrule"
+	"room-service/common/util"
+	"room-service/config"
+	"room-service/constants"
+	errConstant "room-service/constants/error"
+	errBooking "room-service/constants/error/booking"
+	errBookingQuota "room-service/constants/error/bookingQuota"
+	errRoom "room-service/constants/error/room"
+	errRoomSchedule "room-service/constants/error/roomSchedule"
+	errTime "room-service/constants/error/time"
+	errWaitlist "room-service/constants/error/waitlist"
+	"room-service/domain/dto"
+	"room-service/domain/models"
+	"room-service/repositories"
+	penaltyService "room-service/services/penalty"
+	waitlistService "room-service/services/waitlist"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BookingService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IBookingService interface {
+	GetAllWithPagination(context.Context, *dto.BookingRequestParam) (*util.PaginationResult, error)
+	GetAllByUser(context.Context, *dto.BookingRequestParam) (*util.PaginationResult, error)
+	GetAllPending(context.Context, *dto.BookingRequestParam) (*util.PaginationResult, error)
+	GetByUUID(context.Context, string) (*dto.BookingResponse, error)
+	Create(context.Context, *dto.BookingRequest) (*dto.BookingResponse, error)
+	Cancel(context.Context, string, *dto.CancelBookingRequest) (*dto.BookingResponse, error)
+	CancelByAdmin(context.Context, string, *dto.CancelBookingRequest) (*dto.BookingResponse, error)
+	Review(context.Context, *dto.ReviewBookingRequest) ([]dto.BookingResponse, error)
+	AcceptWaitlistOffer(context.Context, string) (*dto.BookingResponse, error)
+	CheckIn(context.Context, string) (*dto.BookingResponse, error)
+	GetAllSeriesByUser(context.Context) ([]dto.BookingSeriesResponse, error)
+	GetSeriesByUUID(context.Context, string) (*dto.BookingSeriesResponse, error)
+	CreateSeries(context.Context, *dto.BookingSeriesRequest) (*dto.BookingSeriesResponse, error)
+	CancelSeries(context.Context, string, *dto.CancelBookingSeriesRequest) (*dto.BookingSeriesResponse, error)
+	CancelSeriesByAdmin(context.Context, string, *dto.CancelBookingSeriesRequest) (*dto.BookingSeriesResponse, error)
+	CheckInByRoom(context.Context, string, *dto.CheckInByRoomRequest) (*dto.BookingResponse, error)
+	MarkNoShows(context.Context) (int, error)
+	LockSchedules(context.Context, *gorm.DB, *models.Booking) error
+}
+
+func NewBookingService(repository repositories.IRepositoryRegistry) IBookingService {
+	return &BookingService{repository: repository}
+}
+
+func (b *BookingService) waitlist() waitlistService.IWaitlistService {
+	return waitlistService.NewWaitlistService(b.repository)
+}
+
+func (b *BookingService) penalty() penaltyService.IPenaltyService {
+	return penaltyService.NewPenaltyService(b.repository)
+}
+
+func (b *BookingService) isStaff(role string) bool {
+	return role == constants.Administrator ||
+		role == constants.Co_Administrator ||
+		role == constants.Staff
+}
+
+func (b *BookingService) toBookingResponse(booking *models.Booking) dto.BookingResponse {
+	schedules := make([]dto.BookingScheduleResponse, 0, len(booking.BookingSchedules))
+	for _, item := range booking.BookingSchedules {
+		schedules = append(schedules, dto.BookingScheduleResponse{
+			UUID:      item.RoomSchedule.UUID,
+			Date:      item.RoomSchedule.Date.Format(time.DateOnly),
+			StartTime: util.FormatClock(item.RoomSchedule.Time.StartTime),
+			EndTime:   util.FormatClock(item.RoomSchedule.Time.EndTime),
+			Status:    item.RoomSchedule.Status.GetStatusString(),
+		})
+	}
+
+	return dto.BookingResponse{
+		UUID:      booking.UUID,
+		UserID:    booking.UserID,
+		UserName:  booking.UserName,
+		RoomID:    booking.Room.UUID,
+		RoomName:  booking.Room.Name,
+		Purpose:   booking.Purpose,
+		Attendees: booking.Attendees,
+		Status:    booking.Status.GetStatusString(),
+		Schedules: schedules,
+
+		ReviewedAt: booking.ReviewedAt,
+		ReviewedBy: booking.ReviewedBy,
+		ReviewNote: booking.ReviewNote,
+
+		CheckedInAt: booking.CheckedInAt,
+		CheckedInBy: booking.CheckedInBy,
+
+		CancelledAt:        booking.CancelledAt,
+		CancelledBy:        booking.CancelledBy,
+		CancellationReason: booking.CancellationReason,
+		CreatedAt:          *booking.CreatedAt,
+		UpdatedAt:          *booking.UpdatedAt,
+	}
+}
+
+func (b *BookingService) paginate(bookings []models.Booking, total int64, param *dto.BookingRequestParam) *util.PaginationResult {
+	bookingResults := make([]dto.BookingResponse, 0, len(bookings))
+	for _, booking := range bookings {
+		bookingResults = append(bookingResults, b.toBookingResponse(&booking))
+	}
+
+	pagination := &util.PaginationParam{
+		Count: total,
+		Page:  param.Page,
+		Limit: param.Limit,
+		Data:  bookingResults,
+	}
+
+	response := util.GeneratePagination(*pagination)
+	return &response
+}
+
+func (b *BookingService) GetAllWithPagination(ctx context.Context, param *dto.BookingRequestParam) (*util.PaginationResult, error) {
+	library, _ := ctx.Value(constants.Library).(string)
+	bookings, total, err := b.repository.GetBooking().FindAllWithPagination(ctx, param, library)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.paginate(bookings, total, param), nil
+}
+
+func (b *BookingService) GetAllByUser(ctx context.Context, param *dto.BookingRequestParam) (*util.PaginationResult, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	bookings, total, err := b.repository.GetBooking().FindAllByUserID(ctx, param, user.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.paginate(bookings, total, param), nil
+}
+
+func (b *BookingService) GetAllPending(ctx context.Context, param *dto.BookingRequestParam) (*util.PaginationResult, error) {
+	library, _ := ctx.Value(constants.Library).(string)
+	bookings, total, err := b.repository.GetBooking().FindAllPendingWithPagination(ctx, param, library)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.paginate(bookings, total, param), nil
+}
+
+// findByUUID returns the booking only to its owner or to staff of the
+// booking's library.
+func (b *BookingService) findByUUID(ctx context.Context, uuid string) (*models.Booking, error) {
+	booking, err := b.repository.GetBooking().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	user := ctx.Value(constants.User).(*clients.UserData)
+	if booking.UserID == user.UUID {
+		return booking, nil
+	}
+
+	if !b.isStaff(user.Role) || (user.Library != "" && booking.Room.Library != user.Library) {
+		return nil, errBooking.ErrBookingNotFound
+	}
+
+	return booking, nil
+}
+
+func (b *BookingService) GetByUUID(ctx context.Context, uuid string) (*dto.BookingResponse, error) {
+	booking, err := b.findByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := b.toBookingResponse(booking)
+	return &response, nil
+}
+
+// findRoom returns the room when it belongs to the caller's library.
+func (b *BookingService) findRoom(ctx context.Context, uuid string) (*models.Room, error) {
+	room, err := b.repository.GetRoom().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	library, _ := ctx.Value(constants.Library).(string)
+	if library != "" && room.Library != library {
+		return nil, errRoom.ErrRoomNotFound
+	}
+
+	return room, nil
+}
+
+// Create books the room from startTime to endTime on the date. The range
+// must be covered exactly by consecutive slots of the room, all of them
+// still available, and must not have started yet.
+func (b *BookingService) Create(ctx context.Context, request *dto.BookingRequest) (*dto.BookingResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	room, err := b.findRoom(ctx, request.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Attendees > room.Capacity {
+		return nil, errBooking.ErrBookingExceedsCapacity
+	}
+
+	startTime, endTime, err := b.parseTimeRange(request.StartTime, request.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse(time.DateOnly, request.Date)
+	if err != nil {
+		return nil, errRoomSchedule.ErrInvalidDateRange
+	}
+
+	var booking *models.Booking
+	err = b.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		err := b.checkQuota(ctx, tx, user, date, endTime.Sub(startTime))
+		if err != nil {
+			return err
+		}
+
+		roomSchedules, err := b.repository.GetRoomSchedule().FindAllByRoomIDAndTimeRangeForUpdate(
+			ctx,
+			tx,
+			room.ID,
+			request.Date,
+			startTime.Format("15:04"),
+			endTime.Format("15:04"),
+		)
+		if err != nil {
+			return err
+		}
+
+		if !b.isContiguous(roomSchedules, startTime.Format("15:04"), endTime.Format("15:04")) {
+			return errBooking.ErrBookingNotContiguous
+		}
+
+		startsAt, err := util.CombineDateAndClock(roomSchedules[0].Date, roomSchedules[0].Time.StartTime)
+		if err != nil {
+			return err
+		}
+
+		if !startsAt.After(time.Now()) {
+			return errRoomSchedule.ErrRoomScheduleStarted
+		}
+
+		booking, err = b.reserve(ctx, tx, user, room, roomSchedules, request.Purpose, request.Attendees, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.GetByUUID(ctx, booking.UUID.String())
+}
+
+// parseTimeRange parses the booked time range and checks it against the
+// maximum booking duration.
+func (b *BookingService) parseTimeRange(start, end string) (time.Time, time.Time, error) {
+	startTime, err := util.ParseClock(start)
+	if err != nil {
+		return time.Time{}, time.Time{}, errTime.ErrInvalidTimeRange
+	}
+
+	endTime, err := util.ParseClock(end)
+	if err != nil {
+		return time.Time{}, time.Time{}, errTime.ErrInvalidTimeRange
+	}
+
+	if !startTime.Before(endTime) {
+		return time.Time{}, time.Time{}, errTime.ErrInvalidTimeRange
+	}
+
+	maxDuration := time.Duration(config.Config.Booking.MaxDurationMinute) * time.Minute
+	if maxDuration > 0 && endTime.Sub(startTime) > maxDuration {
+		return time.Time{}, time.Time{}, errBooking.ErrBookingExceedsMaxDuration
+	}
+
+	return startTime, endTime, nil
+}
+
+// checkQuota enforces the user's booking quota for a new booking of the given
+// duration on the date, and refuses suspended users. It locks the user until
+// tx ends so that concurrent bookings are counted against each other.
+func (b *BookingService) checkQuota(ctx context.Context, tx *gorm.DB, user *clients.UserData, date time.Time, duration time.Duration) error {
+	err := b.repository.GetBooking().LockUser(ctx, tx, user.UUID)
+	if err != nil {
+		return err
+	}
+
+	err = b.penalty().CheckSuspension(ctx, tx, user.UUID)
+	if err != nil {
+		return err
+	}
+
+	quota, err := b.repository.GetBookingQuota().FindByUser(ctx, tx, user.UUID, user.Role)
+	if err != nil || quota == nil {
+		return err
+	}
+
+	today := util.TruncateToDate(time.Now())
+	if quota.MaxDaysInAdvance > 0 && date.After(today.AddDate(0, 0, quota.MaxDaysInAdvance)) {
+		return errBookingQuota.ErrQuotaMaxDaysInAdvance
+	}
+
+	if quota.MaxNoShows > 0 {
+		since := today.AddDate(0, 0, -config.Config.CheckIn.NoShowWindowDays)
+		noShows, err := b.repository.GetBooking().CountNoShowsByUserID(ctx, tx, user.UUID, since)
+		if err != nil {
+			return err
+		}
+
+		if noShows >= int64(quota.MaxNoShows) {
+			return errBookingQuota.ErrQuotaMaxNoShows
+		}
+	}
+
+	if quota.MaxActiveBookings > 0 {
+		active, err := b.repository.GetBooking().CountActiveByUserID(ctx, tx, user.UUID, today)
+		if err != nil {
+			return err
+		}
+
+		if active >= int64(quota.MaxActiveBookings) {
+			return errBookingQuota.ErrQuotaMaxActiveBookings
+		}
+	}
+
+	if quota.MaxHoursPerDay > 0 {
+		times, err := b.repository.GetBooking().FindActiveTimesByUserIDAndDate(ctx, tx, user.UUID, date.Format(time.DateOnly))
+		if err != nil {
+			return err
+		}
+
+		for _, item := range times {
+			startTime, err := util.ParseClock(item.StartTime)
+			if err != nil {
+				return err
+			}
+
+			endTime, err := util.ParseClock(item.EndTime)
+			if err != nil {
+				return err
+			}
+
+			duration += endTime.Sub(startTime)
+		}
+
+		if duration > time.Duration(quota.MaxHoursPerDay)*time.Hour {
+			return errBookingQuota.ErrQuotaMaxHoursPerDay
+		}
+	}
+
+	return nil
+}
+
+// isContiguous reports whether the slots, ordered by start time, follow each
+// other without gaps from startTime to endTime.
+func (b *BookingService) isContiguous(roomSchedules []models.RoomSchedule, startTime, endTime string) bool {
+	next := startTime
+	for _, roomSchedule := range roomSchedules {
+		if util.FormatClock(roomSchedule.Time.StartTime) != next {
+			return false
+		}
+		next = util.FormatClock(roomSchedule.Time.EndTime)
+	}
+
+	return len(roomSchedules) > 0 && next == endTime
+}
+
+// reserve books the locked slots for the user, holding them as Pending
+// instead of Booked when the room requires approval. Besides available slots,
+// slots currently held for the user can be booked.
+func (b *BookingService) reserve(
+	ctx context.Context,
+	tx *gorm.DB,
+	user *clients.UserData,
+	room *models.Room,
+	roomSchedules []models.RoomSchedule,
+	purpose string,
+	attendees int,
+	seriesID *uint,
+) (*models.Booking, error) {
+	scheduleStatus, bookingStatus := constants.Booked, constants.BookingConfirmed
+	if room.RequiresApproval {
+		scheduleStatus, bookingStatus = constants.Pending, constants.BookingPending
+	}
+
+	now := time.Now()
+	bookingSchedules := make([]models.BookingSchedule, 0, len(roomSchedules))
+	for _, roomSchedule := range roomSchedules {
+		if roomSchedule.Status.IsBooked() {
+			return nil, errRoomSchedule.ErrRoomScheduleAlreadyBooked
+		}
+
+		if roomSchedule.Status != constants.Available && !roomSchedule.IsHeldFor(user.UUID, now) {
+			return nil, errRoomSchedule.ErrRoomScheduleNotAvailable
+		}
+
+		err := roomSchedule.TransitionTo(scheduleStatus)
+		if err != nil {
+			return nil, err
+		}
+
+		err = b.repository.GetRoomSchedule().UpdateStatus(ctx, tx, roomSchedule.Status, roomSchedule.UUID.String())
+		if err != nil {
+			return nil, err
+		}
+
+		bookingSchedules = append(bookingSchedules, models.BookingSchedule{
+			RoomScheduleID: roomSchedule.ID,
+		})
+	}
+
+	return b.repository.GetBooking().Create(ctx, tx, &models.Booking{
+		UserID:           user.UUID,
+		UserName:         user.Name,
+		RoomID:           room.ID,
+		Purpose:          purpose,
+		Attendees:        attendees,
+		Status:           bookingStatus,
+		SeriesID:         seriesID,
+		BookingSchedules: bookingSchedules,
+	})
+}
+
+// startsAt returns the start of the earliest slot still held by the booking.
+func (b *BookingService) startsAt(booking *models.Booking) (time.Time, error) {
+	var startsAt time.Time
+	for _, item := range booking.BookingSchedules {
+		start, err := util.CombineDateAndClock(item.RoomSchedule.Date, item.RoomSchedule.Time.StartTime)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if startsAt.IsZero() || start.Before(startsAt) {
+			startsAt = start
+		}
+	}
+
+	return startsAt, nil
+}
+
+// LockSchedules locks the slots the booking still holds until tx ends and
+// refreshes them in place, so their status can be changed safely. The
+// schedules preloaded with the booking are not locked themselves.
+func (b *BookingService) LockSchedules(ctx context.Context, tx *gorm.DB, booking *models.Booking) error {
+	if len(booking.BookingSchedules) == 0 {
+		return nil
+	}
+
+	uuids := make([]string, 0, len(booking.BookingSchedules))
+	for _, item := range booking.BookingSchedules {
+		uuids = append(uuids, item.RoomSchedule.UUID.String())
+	}
+
+	roomSchedules, err := b.repository.GetRoomSchedule().FindAllByUUIDsForUpdate(ctx, tx, uuids)
+	if err != nil {
+		return err
+	}
+
+	locked := make(map[uint]models.RoomSchedule, len(roomSchedules))
+	for _, roomSchedule := range roomSchedules {
+		locked[roomSchedule.ID] = roomSchedule
+	}
+
+	for i, item := range booking.BookingSchedules {
+		roomSchedule, ok := locked[item.RoomScheduleID]
+		if !ok {
+			return errRoomSchedule.ErrRoomScheduleNotFound
+		}
+		booking.BookingSchedules[i].RoomSchedule = roomSchedule
+	}
+
+	return nil
+}
+
+// cancelBooking releases every slot of the booking back to Available. Owners
+// are bound by the cancellation cutoff, staff are not, and owners cancelling
+// within the late cancellation window get a strike.
+func (b *BookingService) cancelBooking(ctx context.Context, tx *gorm.DB, uuid, reason string, enforceCutoff bool) error {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	booking, err := b.repository.GetBooking().FindByUUIDForUpdate(ctx, tx, uuid)
+	if err != nil {
+		return err
+	}
+
+	if booking.Status == constants.BookingCancelled {
+		return errBooking.ErrBookingAlreadyCancelled
+	}
+
+	if booking.Status != constants.BookingConfirmed && booking.Status != constants.BookingPending {
+		return errBooking.ErrBookingNotCancellable
+	}
+
+	if enforceCutoff {
+		startsAt, err := b.startsAt(booking)
+		if err != nil {
+			return err
+		}
+
+		cutoff := time.Duration(config.Config.Booking.CancellationCutoffMinute) * time.Minute
+		if time.Now().After(startsAt.Add(-cutoff)) {
+			return errBooking.ErrBookingCancellationCutoff
+		}
+
+		lateWindow := time.Duration(config.Config.Penalty.LateCancellationMinute) * time.Minute
+		if lateWindow > 0 && time.Now().After(startsAt.Add(-lateWindow)) {
+			err = b.penalty().AddStrike(ctx, tx, booking, constants.StrikeLateCancellation)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = b.LockSchedules(ctx, tx, booking)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range booking.BookingSchedules {
+		err = item.RoomSchedule.TransitionTo(constants.Available)
+		if err != nil {
+			return err
+		}
+
+		err = b.repository.GetRoomSchedule().UpdateStatus(ctx, tx, item.RoomSchedule.Status, item.RoomSchedule.UUID.String())
+		if err != nil {
+			return err
+		}
+
+		err = b.waitlist().OfferNext(ctx, tx, &item.RoomSchedule)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = b.repository.GetBooking().ReleaseSchedules(ctx, tx, booking.ID)
+	if err != nil {
+		return err
+	}
+
+	return b.repository.GetBooking().Cancel(ctx, tx, uuid, user.UUID, reason)
+}
+
+func (b *BookingService) cancel(ctx context.Context, uuid string, request *dto.CancelBookingRequest, enforceCutoff bool) (*dto.BookingResponse, error) {
+	err := b.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		return b.cancelBooking(ctx, tx, uuid, request.Reason, enforceCutoff)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.GetByUUID(ctx, uuid)
+}
+
+func (b *BookingService) Cancel(ctx context.Context, uuid string, request *dto.CancelBookingRequest) (*dto.BookingResponse, error) {
+	booking, err := b.findByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	user := ctx.Value(constants.User).(*clients.UserData)
+	if booking.UserID != user.UUID {
+		return nil, errBooking.ErrBookingNotFound
+	}
+
+	return b.cancel(ctx, uuid, request, true)
+}
+
+func (b *BookingService) CancelByAdmin(ctx context.Context, uuid string, request *dto.CancelBookingRequest) (*dto.BookingResponse, error) {
+	_, err := b.findByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.cancel(ctx, uuid, request, false)
+}
+
+// Review approves or rejects a batch of pending bookings. The batch is
+// applied atomically: if any booking cannot be reviewed, none are.
+// Slots of rejected bookings are marked Rejected and then given back.
+func (b *BookingService) Review(ctx context.Context, request *dto.ReviewBookingRequest) ([]dto.BookingResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	scheduleStatuses, bookingStatus := []constants.RoomScheduleStatus{constants.Approved}, constants.BookingConfirmed
+	if request.Action == "reject" {
+		scheduleStatuses = []constants.RoomScheduleStatus{constants.Rejected, constants.Available}
+		bookingStatus = constants.BookingRejected
+	}
+
+	err := b.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		for _, uuid := range request.BookingIDs {
+			booking, err := b.repository.GetBooking().FindByUUIDForUpdate(ctx, tx, uuid)
+			if err != nil {
+				return err
+			}
+
+			if user.Library != "" && booking.Room.Library != user.Library {
+				return errBooking.ErrBookingNotFound
+			}
+
+			if booking.Status != constants.BookingPending {
+				return errBooking.ErrBookingNotPending
+			}
+
+			err = b.LockSchedules(ctx, tx, booking)
+			if err != nil {
+				return err
+			}
+
+			for _, item := range booking.BookingSchedules {
+				for _, scheduleStatus := range scheduleStatuses {
+					err = item.RoomSchedule.TransitionTo(scheduleStatus)
+					if err != nil {
+						return err
+					}
+
+					err = b.repository.GetRoomSchedule().UpdateStatus(ctx, tx, item.RoomSchedule.Status, item.RoomSchedule.UUID.String())
+					if err != nil {
+						return err
+					}
+				}
+
+				err = b.waitlist().OfferNext(ctx, tx, &item.RoomSchedule)
+				if err != nil {
+					return err
+				}
+			}
+
+			if bookingStatus == constants.BookingRejected {
+				err = b.repository.GetBooking().ReleaseSchedules(ctx, tx, booking.ID)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = b.repository.GetBooking().Review(ctx, tx, uuid, bookingStatus, user.UUID, request.Note)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.BookingResponse, 0, len(request.BookingIDs))
+	for _, uuid := range request.BookingIDs {
+		result, err := b.GetByUUID(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+// AcceptWaitlistOffer books the slot held for the user by a waitlist offer,
+// under the same quota and capacity rules as a regular booking.
+func (b *BookingService) AcceptWaitlistOffer(ctx context.Context, uuid string) (*dto.BookingResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	var booking *models.Booking
+	err := b.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		entry, err := b.repository.GetWaitlist().FindByUUIDForUpdate(ctx, tx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if entry.UserID != user.UUID {
+			return errWaitlist.ErrWaitlistNotFound
+		}
+
+		if entry.Status != constants.WaitlistOffered {
+			return errWaitlist.ErrWaitlistNotOffered
+		}
+
+		roomSchedule, err := b.repository.GetRoomSchedule().FindByIDForUpdate(ctx, tx, entry.RoomScheduleID)
+		if err != nil {
+			return err
+		}
+
+		if !roomSchedule.IsHeldFor(user.UUID, time.Now()) {
+			return errWaitlist.ErrWaitlistOfferExpired
+		}
+
+		if entry.Attendees > roomSchedule.Room.Capacity {
+			return errBooking.ErrBookingExceedsCapacity
+		}
+
+		startTime, err := util.ParseClock(roomSchedule.Time.StartTime)
+		if err != nil {
+			return err
+		}
+
+		endTime, err := util.ParseClock(roomSchedule.Time.EndTime)
+		if err != nil {
+			return err
+		}
+
+		err = b.checkQuota(ctx, tx, user, roomSchedule.Date, endTime.Sub(startTime))
+		if err != nil {
+			return err
+		}
+
+		booking, err = b.reserve(ctx, tx, user, &roomSchedule.Room, []models.RoomSchedule{*roomSchedule}, entry.Purpose, entry.Attendees, nil)
+		if err != nil {
+			return err
+		}
+
+		return b.repository.GetWaitlist().UpdateStatus(ctx, tx, uuid, constants.WaitlistAccepted)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.GetByUUID(ctx, booking.UUID.String())
+}
+
+// checkIn marks a confirmed booking and its slots as checked in, as long as
+// it starts within the configured window around now.
+func (b *BookingService) checkIn(ctx context.Context, tx *gorm.DB, booking *models.Booking, checkedInBy *uuid.UUID) error {
+	if booking.Status == constants.BookingCheckedIn {
+		return errBooking.ErrBookingAlreadyCheckedIn
+	}
+
+	if booking.Status != constants.BookingConfirmed {
+		return errBooking.ErrBookingNotCheckInable
+	}
+
+	startsAt, err := b.startsAt(booking)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	opensAt := startsAt.Add(-time.Duration(config.Config.CheckIn.EarlyMinute) * time.Minute)
+	closesAt := startsAt.Add(time.Duration(config.Config.CheckIn.GraceMinute) * time.Minute)
+	if now.Before(opensAt) || now.After(closesAt) {
+		return errBooking.ErrBookingCheckInWindow
+	}
+
+	err = b.LockSchedules(ctx, tx, booking)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range booking.BookingSchedules {
+		err = item.RoomSchedule.TransitionTo(constants.CheckedIn)
+		if err != nil {
+			return err
+		}
+
+		err = b.repository.GetRoomSchedule().UpdateStatus(ctx, tx, item.RoomSchedule.Status, item.RoomSchedule.UUID.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.repository.GetBooking().CheckIn(ctx, tx, booking.UUID.String(), checkedInBy)
+}
+
+// CheckIn checks in the caller's own booking.
+func (b *BookingService) CheckIn(ctx context.Context, uuid string) (*dto.BookingResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	err := b.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		booking, err := b.repository.GetBooking().FindByUUIDForUpdate(ctx, tx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if booking.UserID != user.UUID {
+			return errBooking.ErrBookingNotFound
+		}
+
+		return b.checkIn(ctx, tx, booking, &user.UUID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.GetByUUID(ctx, uuid)
+}
+
+// CheckInByRoom checks in the booking currently due in the room, for anyone
+// holding the room's QR token.
+func (b *BookingService) CheckInByRoom(ctx context.Context, roomUUID string, request *dto.CheckInByRoomRequest) (*dto.BookingResponse, error) {
+	room, err := b.repository.GetRoom().FindByUUID(ctx, roomUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if room.CheckInToken == "" || subtle.ConstantTimeCompare([]byte(room.CheckInToken), []byte(request.Token)) != 1 {
+		return nil, errBooking.ErrBookingInvalidCheckIn
+	}
+
+	now := time.Now()
+	from := now.Add(-time.Duration(config.Config.CheckIn.GraceMinute) * time.Minute)
+	to := now.Add(time.Duration(config.Config.CheckIn.EarlyMinute) * time.Minute)
+	var booking *models.Booking
+	err = b.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		due, err := b.repository.GetBooking().FindDueForCheckInByRoomID(ctx, tx, room.ID, from, to)
+		if err != nil {
+			return err
+		}
+
+		booking, err = b.repository.GetBooking().FindByUUIDForUpdate(ctx, tx, due.UUID.String())
+		if err != nil {
+			return err
+		}
+
+		return b.checkIn(ctx, tx, booking, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err = b.repository.GetBooking().FindByUUID(ctx, booking.UUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	response := b.toBookingResponse(booking)
+	return &response, nil
+}
+
+// MarkNoShows marks the confirmed bookings nobody checked in within the
+// grace window as no-shows and gives their owners a strike. Their slots that
+// have started become NoShow and the rest are released back to Available. It
+// returns how many bookings were marked.
+func (b *BookingService) MarkNoShows(ctx context.Context) (int, error) {
+	grace := time.Duration(config.Config.CheckIn.GraceMinute) * time.Minute
+	bookings, err := b.repository.GetBooking().FindAllMissedCheckIn(ctx, time.Now().Add(-grace))
+	if err != nil {
+		return 0, err
+	}
+
+	marked := 0
+	for _, item := range bookings {
+		isMarked := false
+		err = b.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+			booking, err := b.repository.GetBooking().FindByUUIDForUpdate(ctx, tx, item.UUID.String())
+			if err != nil {
+				return err
+			}
+
+			if booking.Status != constants.BookingConfirmed {
+				return nil
+			}
+
+			startsAt, err := b.startsAt(booking)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			if !now.After(startsAt.Add(grace)) {
+				return nil
+			}
+
+			err = b.LockSchedules(ctx, tx, booking)
+			if err != nil {
+				return err
+			}
+
+			for _, schedule := range booking.BookingSchedules {
+				slotStartsAt, err := util.CombineDateAndClock(schedule.RoomSchedule.Date, schedule.RoomSchedule.Time.StartTime)
+				if err != nil {
+					return err
+				}
+
+				status := constants.NoShow
+				if slotStartsAt.After(now) {
+					status = constants.Available
+				}
+
+				err = schedule.RoomSchedule.TransitionTo(status)
+				if err != nil {
+					return err
+				}
+
+				err = b.repository.GetRoomSchedule().UpdateStatus(ctx, tx, schedule.RoomSchedule.Status, schedule.RoomSchedule.UUID.String())
+				if err != nil {
+					return err
+				}
+
+				err = b.waitlist().OfferNext(ctx, tx, &schedule.RoomSchedule)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = b.repository.GetBooking().ReleaseSchedules(ctx, tx, booking.ID)
+			if err != nil {
+				return err
+			}
+
+			err = b.penalty().AddStrike(ctx, tx, booking, constants.StrikeNoShow)
+			if err != nil {
+				return err
+			}
+
+			isMarked = true
+			return b.repository.GetBooking().MarkNoShow(ctx, tx, booking.UUID.String())
+		})
+		if err != nil {
+			return marked, err
+		}
+
+		if isMarked {
+			marked++
+		}
+	}
+
+	return marked, nil
+}
+
+// occurrenceDate returns the date of a series occurrence, which all of its
+// slots share.
+func (b *BookingService) occurrenceDate(booking *models.Booking) string {
+	if len(booking.BookingSchedules) == 0 {
+		return ""
+	}
+
+	return booking.BookingSchedules[0].RoomSchedule.Date.Format(time.DateOnly)
+}
+
+func (b *BookingService) toBookingSeriesResponse(series *models.BookingSeries) dto.BookingSeriesResponse {
+	occurrences := make([]dto.BookingSeriesOccurrenceResponse, 0, len(series.Bookings))
+	for _, booking := range series.Bookings {
+		occurrences = append(occurrences, dto.BookingSeriesOccurrenceResponse{
+			Date:      b.occurrenceDate(&booking),
+			BookingID: &booking.UUID,
+			Status:    booking.Status.GetStatusString(),
+		})
+	}
+
+	sort.Slice(occurrences, func(i, j int) bool {
+		return occurrences[i].Date < occurrences[j].Date
+	})
+
+	return dto.BookingSeriesResponse{
+		UUID:        series.UUID,
+		UserID:      series.UserID,
+		UserName:    series.UserName,
+		RoomID:      series.Room.UUID,
+		RoomName:    series.Room.Name,
+		RRule:       series.RRule,
+		StartDate:   series.StartDate.Format(time.DateOnly),
+		EndDate:     series.EndDate.Format(time.DateOnly),
+		StartTime:   util.FormatClock(series.StartTime),
+		EndTime:     util.FormatClock(series.EndTime),
+		Purpose:     series.Purpose,
+		Attendees:   series.Attendees,
+		Occurrences: occurrences,
+		CancelledAt: series.CancelledAt,
+		CreatedAt:   series.CreatedAt,
+	}
+}
+
+// findSeries returns the series only to its owner or to staff of the room's
+// library.
+func (b *BookingService) findSeries(ctx context.Context, uuid string) (*models.BookingSeries, error) {
+	series, err := b.repository.GetBookingSeries().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	user := ctx.Value(constants.User).(*clients.UserData)
+	if series.UserID == user.UUID {
+		return series, nil
+	}
+
+	if !b.isStaff(user.Role) || (user.Library != "" && series.Room.Library != user.Library) {
+		return nil, errBooking.ErrBookingSeriesNotFound
+	}
+
+	return series, nil
+}
+
+func (b *BookingService) GetAllSeriesByUser(ctx context.Context) ([]dto.BookingSeriesResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	series, err := b.repository.GetBookingSeries().FindAllByUserID(ctx, user.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	seriesResults := make([]dto.BookingSeriesResponse, 0, len(series))
+	for _, item := range series {
+		seriesResults = append(seriesResults, b.toBookingSeriesResponse(&item))
+	}
+
+	return seriesResults, nil
+}
+
+func (b *BookingService) GetSeriesByUUID(ctx context.Context, uuid string) (*dto.BookingSeriesResponse, error) {
+	series, err := b.findSeries(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := b.toBookingSeriesResponse(series)
+	return &response, nil
+}
+
+// reserveOccurrence books one date of the series under the same rules as a
+// regular booking.
+func (b *BookingService) reserveOccurrence(
+	ctx context.Context,
+	tx *gorm.DB,
+	user *clients.UserData,
+	room *models.Room,
+	series *models.BookingSeries,
+	date time.Time,
+	startTime, endTime time.Time,
+) error {
+	startsAt, err := util.CombineDateAndClock(date, series.StartTime)
+	if err != nil {
+		return err
+	}
+
+	if !startsAt.After(time.Now()) {
+		return errRoomSchedule.ErrRoomScheduleStarted
+	}
+
+	err = b.checkQuota(ctx, tx, user, date, endTime.Sub(startTime))
+	if err != nil {
+		return err
+	}
+
+	roomSchedules, err := b.repository.GetRoomSchedule().FindAllByRoomIDAndTimeRangeForUpdate(
+		ctx,
+		tx,
+		room.ID,
+		date.Format(time.DateOnly),
+		series.StartTime,
+		series.EndTime,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !b.isContiguous(roomSchedules, series.StartTime, series.EndTime) {
+		return errBooking.ErrBookingNotContiguous
+	}
+
+	_, err = b.reserve(ctx, tx, user, room, roomSchedules, series.Purpose, series.Attendees, &series.ID)
+	return err
+}
+
+// CreateSeries books the room at the same time on every date the recurrence
+// rule produces from startDate through endDate. Each date is booked in its
+// own savepoint so that every conflict can be reported. Unless conflicts are
+// to be skipped, a single conflict fails the whole series and nothing is
+// booked.
+func (b *BookingService) CreateSeries(ctx context.Context, request *dto.BookingSeriesRequest) (*dto.BookingSeriesResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	room, err := b.findRoom(ctx, request.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Attendees > room.Capacity {
+		return nil, errBooking.ErrBookingExceedsCapacity
+	}
+
+	startTime, endTime, err := b.parseTimeRange(request.StartTime, request.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, err := time.Parse(time.DateOnly, request.StartDate)
+	if err != nil {
+		return nil, errRoomSchedule.ErrInvalidDateRange
+	}
+
+	endDate, err := time.Parse(time.DateOnly, request.EndDate)
+	if err != nil || endDate.Before(startDate) {
+		return nil, errRoomSchedule.ErrInvalidDateRange
+	}
+
+	rule, err := rrule.Parse(request.RRule)
+	if err != nil {
+		return nil, errBooking.ErrBookingSeriesInvalidRule
+	}
+
+	maxOccurrences := config.Config.BookingSeries.MaxOccurrences
+	limit := 0
+	if maxOccurrences > 0 {
+		limit = maxOccurrences + 1
+	}
+
+	dates := rule.Between(startDate, endDate, limit)
+	if len(dates) == 0 {
+		return nil, errBooking.ErrBookingSeriesEmpty
+	}
+
+	if maxOccurrences > 0 && len(dates) > maxOccurrences {
+		return nil, errBooking.ErrBookingSeriesTooLong
+	}
+
+	var (
+		series    *models.BookingSeries
+		conflicts []errBooking.SeriesConflict
+	)
+	err = b.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		var err error
+		series, err = b.repository.GetBookingSeries().Create(ctx, tx, &models.BookingSeries{
+			UserID:    user.UUID,
+			UserName:  user.Name,
+			RoomID:    room.ID,
+			RRule:     request.RRule,
+			StartDate: startDate,
+			EndDate:   endDate,
+			StartTime: startTime.Format("15:04"),
+			EndTime:   endTime.Format("15:04"),
+			Purpose:   request.Purpose,
+			Attendees: request.Attendees,
+		})
+		if err != nil {
+			return err
+		}
+
+		booked := 0
+		for _, date := range dates {
+			err = tx.Transaction(func(tx *gorm.DB) error {
+				return b.reserveOccurrence(ctx, tx, user, room, series, date, startTime, endTime)
+			})
+			if err == nil {
+				booked++
+				continue
+			}
+
+			var statusErr errConstant.StatusError
+			if !errors.As(err, &statusErr) || statusErr.HTTPStatus() >= http.StatusInternalServerError {
+				return err
+			}
+
+			conflicts = append(conflicts, errBooking.SeriesConflict{
+				Date:   date.Format(time.DateOnly),
+				Reason: err.Error(),
+			})
+		}
+
+		if booked == 0 || (len(conflicts) > 0 && !request.SkipConflicts) {
+			return &errBooking.SeriesConflictError{Conflicts: conflicts}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := b.GetSeriesByUUID(ctx, series.UUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, conflict := range conflicts {
+		response.Occurrences = append(response.Occurrences, dto.BookingSeriesOccurrenceResponse{
+			Date:     conflict.Date,
+			Conflict: conflict.Reason,
+		})
+	}
+
+	sort.Slice(response.Occurrences, func(i, j int) bool {
+		return response.Occurrences[i].Date < response.Occurrences[j].Date
+	})
+
+	return response, nil
+}
+
+// cancelSeries cancels either the occurrence on the requested date or every
+// occurrence from that date, or from today, on. When the remainder is
+// cancelled, occurrences that have started, and for owners those past the
+// cancellation cutoff, are kept, and the series is marked as cancelled.
+func (b *BookingService) cancelSeries(ctx context.Context, uuid string, request *dto.CancelBookingSeriesRequest, enforceCutoff bool) (*dto.BookingSeriesResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	err := b.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		series, err := b.repository.GetBookingSeries().FindByUUIDForUpdate(ctx, tx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if request.Scope == constants.SeriesCancelOccurrence {
+			for _, booking := range series.Bookings {
+				if b.occurrenceDate(&booking) == request.Date {
+					return b.cancelBooking(ctx, tx, booking.UUID.String(), request.Reason, enforceCutoff)
+				}
+			}
+			return errBooking.ErrBookingSeriesNoOccurrence
+		}
+
+		if series.CancelledAt != nil {
+			return errBooking.ErrBookingSeriesAlreadyCancelled
+		}
+
+		now := time.Now()
+		from := now
+		if request.Date != "" {
+			date, err := time.ParseInLocation(time.DateOnly, request.Date, time.Local)
+			if err != nil {
+				return errRoomSchedule.ErrInvalidDateRange
+			}
+
+			if date.After(from) {
+				from = date
+			}
+		}
+
+		if enforceCutoff {
+			cutoff := now.Add(time.Duration(config.Config.Booking.CancellationCutoffMinute) * time.Minute)
+			if cutoff.After(from) {
+				from = cutoff
+			}
+		}
+
+		for _, booking := range series.Bookings {
+			if booking.Status != constants.BookingConfirmed && booking.Status != constants.BookingPending {
+				continue
+			}
+
+			startsAt, err := b.startsAt(&booking)
+			if err != nil {
+				return err
+			}
+
+			if !startsAt.After(from) {
+				continue
+			}
+
+			err = b.cancelBooking(ctx, tx, booking.UUID.String(), request.Reason, enforceCutoff)
+			if err != nil {
+				return err
+			}
+		}
+
+		return b.repository.GetBookingSeries().Cancel(ctx, tx, uuid, user.UUID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.GetSeriesByUUID(ctx, uuid)
+}
+
+func (b *BookingService) CancelSeries(ctx context.Context, uuid string, request *dto.CancelBookingSeriesRequest) (*dto.BookingSeriesResponse, error) {
+	series, err := b.findSeries(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	user := ctx.Value(constants.User).(*clients.UserData)
+	if series.UserID != user.UUID {
+		return nil, errBooking.ErrBookingSeriesNotFound
+	}
+
+	return b.cancelSeries(ctx, uuid, request, true)
+}
+
+func (b *BookingService) CancelSeriesByAdmin(ctx context.Context, uuid string, request *dto.CancelBookingSeriesRequest) (*dto.BookingSeriesResponse, error) {
+	_, err := b.findSeries(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.cancelSeries(ctx, uuid, request, false)
+}
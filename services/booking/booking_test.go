@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	clients "room-service/clients/user"
+	"room-service/constants"
+	errRoomSchedule "room-service/constants/error/roomSchedule"
+	"room-service/domain/dto"
+	"room-service/domain/models"
+	"room-service/repositories"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB connects to the Postgres database in TEST_DATABASE_URL and
+// migrates the models into a schema of their own, dropped when the test ends.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	uri := os.Getenv("TEST_DATABASE_URL")
+	if uri == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	config := &gorm.Config{TranslateError: true, Logger: logger.Default.LogMode(logger.Silent)}
+	admin, err := gorm.Open(postgres.Open(uri), config)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	err = admin.Exec("CREATE SCHEMA " + schema).Error
+	if err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+	})
+
+	separator := "?"
+	if strings.Contains(uri, "?") {
+		separator = "&"
+	}
+
+	db, err := gorm.Open(postgres.Open(uri+separator+"search_path="+schema), config)
+	if err != nil {
+		t.Fatalf("connect to schema: %v", err)
+	}
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		if err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	err = db.AutoMigrate(
+		&models.Room{},
+		&models.RoomSchedule{},
+		&models.Time{},
+		&models.Booking{},
+		&models.BookingSchedule{},
+		&models.Closure{},
+		&models.RoomSlotTemplate{},
+		&models.BookingQuota{},
+		&models.Amenity{},
+		&models.CalendarFeed{},
+		&models.WaitlistEntry{},
+		&models.Strike{},
+		&models.Suspension{},
+		&models.BookingSeries{},
+	)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	return db
+}
+
+func TestCreateConcurrentRequestsForTheSameSlot(t *testing.T) {
+	db := openTestDB(t)
+	library := constants.Telkom_University_Bandung
+
+	room := models.Room{
+		UUID:     uuid.New(),
+		Library:  library,
+		Image:    []string{},
+		Code:     "R-101",
+		Name:     "Discussion Room",
+		Capacity: 10,
+	}
+	scheduleTime := models.Time{UUID: uuid.New(), Library: library, StartTime: "08:00:00", EndTime: "09:00:00"}
+	err := db.Create(&room).Error
+	if err == nil {
+		err = db.Create(&scheduleTime).Error
+	}
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	date := time.Now().AddDate(0, 0, 1).Format(time.DateOnly)
+	err = db.Exec(
+		"INSERT INTO room_schedules (uuid, room_id, time_id, date, status) VALUES (?, ?, ?, ?, ?)",
+		uuid.New(), room.ID, scheduleTime.ID, date, constants.Available,
+	).Error
+	if err != nil {
+		t.Fatalf("seed schedule: %v", err)
+	}
+
+	service := NewBookingService(repositories.NewRepositoryRegistry(db))
+	request := &dto.BookingRequest{
+		RoomID:    room.UUID.String(),
+		Date:      date,
+		StartTime: "08:00",
+		EndTime:   "09:00",
+		Purpose:   "Group study",
+		Attendees: 2,
+	}
+
+	const requests = 10
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+		errs  = make([]error, requests)
+	)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := context.WithValue(context.Background(), constants.User, &clients.UserData{
+				UUID:    uuid.New(),
+				Name:    fmt.Sprintf("Student %d", i),
+				Role:    constants.Student,
+				Library: library,
+			})
+			ctx = context.WithValue(ctx, constants.Library, library)
+
+			<-start
+			_, errs[i] = service.Create(ctx, request)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	succeeded := 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.Is(err, errRoomSchedule.ErrRoomScheduleAlreadyBooked),
+			errors.Is(err, errRoomSchedule.ErrRoomScheduleNotAvailable):
+		default:
+			t.Errorf("request %d: unexpected error: %v", i, err)
+		}
+	}
+
+	if succeeded != 1 {
+		t.Fatalf("%d requests succeeded, want exactly 1", succeeded)
+	}
+
+	var bookings int64
+	err = db.Model(&models.BookingSchedule{}).Where("released_at IS NULL").Count(&bookings).Error
+	if err != nil {
+		t.Fatalf("count bookings: %v", err)
+	}
+
+	if bookings != 1 {
+		t.Fatalf("slot is held by %d bookings, want 1", bookings)
+	}
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"room-service/domain/dto"
+	"room-service/domain/models"
+	"room-service/repositories"
+)
+
+type BookingQuotaService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IBookingQuotaService interface {
+	GetAll(context.Context) ([]dto.BookingQuotaResponse, error)
+	GetByUUID(context.Context, string) (*dto.BookingQuotaResponse, error)
+	Create(context.Context, *dto.BookingQuotaRequest) (*dto.BookingQuotaResponse, error)
+	Update(context.Context, string, *dto.BookingQuotaRequest) (*dto.BookingQuotaResponse, error)
+	Delete(context.Context, string) error
+}
+
+func NewBookingQuotaService(repository repositories.IRepositoryRegistry) IBookingQuotaService {
+	return &BookingQuotaService{repository: repository}
+}
+
+func (b *BookingQuotaService) toBookingQuotaResponse(quota *models.BookingQuota) dto.BookingQuotaResponse {
+	return dto.BookingQuotaResponse{
+		UUID:              quota.UUID,
+		Role:              quota.Role,
+		UserID:            quota.UserID,
+		MaxHoursPerDay:    quota.MaxHoursPerDay,
+		MaxActiveBookings: quota.MaxActiveBookings,
+		MaxDaysInAdvance:  quota.MaxDaysInAdvance,
+		MaxNoShows:        quota.MaxNoShows,
+		CreatedAt:         quota.CreatedAt,
+		UpdatedAt:         quota.UpdatedAt,
+	}
+}
+
+func (b *BookingQuotaService) GetAll(ctx context.Context) ([]dto.BookingQuotaResponse, error) {
+	quotas, err := b.repository.GetBookingQuota().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaResults := make([]dto.BookingQuotaResponse, 0, len(quotas))
+	for _, quota := range quotas {
+		quotaResults = append(quotaResults, b.toBookingQuotaResponse(&quota))
+	}
+
+	return quotaResults, nil
+}
+
+func (b *BookingQuotaService) GetByUUID(ctx context.Context, uuid string) (*dto.BookingQuotaResponse, error) {
+	quota, err := b.repository.GetBookingQuota().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := b.toBookingQuotaResponse(quota)
+	return &response, nil
+}
+
+func (b *BookingQuotaService) Create(ctx context.Context, request *dto.BookingQuotaRequest) (*dto.BookingQuotaResponse, error) {
+	quota, err := b.repository.GetBookingQuota().Create(ctx, &models.BookingQuota{
+		Role:              request.Role,
+		UserID:            request.UserID,
+		MaxHoursPerDay:    request.MaxHoursPerDay,
+		MaxActiveBookings: request.MaxActiveBookings,
+		MaxDaysInAdvance:  request.MaxDaysInAdvance,
+		MaxNoShows:        request.MaxNoShows,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := b.toBookingQuotaResponse(quota)
+	return &response, nil
+}
+
+func (b *BookingQuotaService) Update(ctx context.Context, uuid string, request *dto.BookingQuotaRequest) (*dto.BookingQuotaResponse, error) {
+	_, err := b.repository.GetBookingQuota().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	quota, err := b.repository.GetBookingQuota().Update(ctx, uuid, &models.BookingQuota{
+		Role:              request.Role,
+		UserID:            request.UserID,
+		MaxHoursPerDay:    request.MaxHoursPerDay,
+		MaxActiveBookings: request.MaxActiveBookings,
+		MaxDaysInAdvance:  request.MaxDaysInAdvance,
+		MaxNoShows:        request.MaxNoShows,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := b.toBookingQuotaResponse(quota)
+	return &response, nil
+}
+
+func (b *BookingQuotaService) Delete(ctx context.Context, uuid string) error {
+	_, err := b.repository.GetBookingQuota().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return b.repository.GetBookingQuota().Delete(ctx, uuid)
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	clients "room-service/clients/user"
+	"room-service/common/ical"
+	"room-service/common/util"
+	"room-service/config"
+	"room-service/constants"
+	errCalendarFeed "room-service/constants/error/calendarFeed"
+	errRoom "room-service/constants/error/room"
+	"room-service/domain/dto"
+	"room-service/domain/models"
+	"room-service/repositories"
+	"time"
+)
+
+type CalendarFeedService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type ICalendarFeedService interface {
+	GetAllByUser(context.Context) ([]dto.CalendarFeedResponse, error)
+	Create(context.Context, *dto.CalendarFeedRequest) (*dto.CalendarFeedResponse, error)
+	Revoke(context.Context, string) error
+	Render(context.Context, string, string) ([]byte, error)
+}
+
+func NewCalendarFeedService(repository repositories.IRepositoryRegistry) ICalendarFeedService {
+	return &CalendarFeedService{repository: repository}
+}
+
+func (c *CalendarFeedService) sign(feed *models.CalendarFeed) string {
+	return util.Sign(config.Config.SignatureKey, feed.UUID.String(), feed.Secret)
+}
+
+func (c *CalendarFeedService) toCalendarFeedResponse(feed *models.CalendarFeed) dto.CalendarFeedResponse {
+	response := dto.CalendarFeedResponse{
+		UUID:      feed.UUID,
+		Scope:     feed.Scope,
+		Token:     c.sign(feed),
+		CreatedAt: feed.CreatedAt,
+	}
+	if feed.Room != nil {
+		response.RoomID = &feed.Room.UUID
+		response.RoomName = feed.Room.Name
+	}
+
+	return response
+}
+
+func (c *CalendarFeedService) GetAllByUser(ctx context.Context) ([]dto.CalendarFeedResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	feeds, err := c.repository.GetCalendarFeed().FindAllByCreatedBy(ctx, user.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	feedResults := make([]dto.CalendarFeedResponse, 0, len(feeds))
+	for _, feed := range feeds {
+		feedResults = append(feedResults, c.toCalendarFeedResponse(&feed))
+	}
+
+	return feedResults, nil
+}
+
+func (c *CalendarFeedService) Create(ctx context.Context, request *dto.CalendarFeedRequest) (*dto.CalendarFeedResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	secret, err := util.GenerateToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &models.CalendarFeed{
+		Secret:    secret,
+		Scope:     request.Scope,
+		Library:   user.Library,
+		CreatedBy: user.UUID,
+	}
+
+	if request.Scope == constants.CalendarFeedScopeRoom {
+		room, err := c.repository.GetRoom().FindByUUID(ctx, request.RoomID)
+		if err != nil {
+			return nil, err
+		}
+		if room.Library != user.Library {
+			return nil, errRoom.ErrRoomNotFound
+		}
+		feed.RoomID = &room.ID
+		feed.Room = room
+	} else {
+		feed.UserID = &user.UUID
+	}
+
+	feed, err = c.repository.GetCalendarFeed().Create(ctx, feed)
+	if err != nil {
+		return nil, err
+	}
+
+	response := c.toCalendarFeedResponse(feed)
+	return &response, nil
+}
+
+// Revoke stops a feed from being served. Besides its owner, administrators
+// of the feed's library may revoke it.
+func (c *CalendarFeedService) Revoke(ctx context.Context, uuid string) error {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	feed, err := c.repository.GetCalendarFeed().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	isAdmin := user.Role == constants.Administrator || user.Role == constants.Co_Administrator
+	if feed.CreatedBy != user.UUID && !(isAdmin && feed.Library == user.Library) {
+		return errCalendarFeed.ErrCalendarFeedForbidden
+	}
+
+	return c.repository.GetCalendarFeed().Revoke(ctx, uuid)
+}
+
+func (c *CalendarFeedService) toEvent(feed *models.CalendarFeed, booking *models.Booking) (*ical.Event, error) {
+	var start, end time.Time
+	for _, item := range booking.BookingSchedules {
+		slotStart, err := util.CombineDateAndClock(item.RoomSchedule.Date, item.RoomSchedule.Time.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		slotEnd, err := util.CombineDateAndClock(item.RoomSchedule.Date, item.RoomSchedule.Time.EndTime)
+		if err != nil {
+			return nil, err
+		}
+
+		if start.IsZero() || slotStart.Before(start) {
+			start = slotStart
+		}
+		if end.IsZero() || slotEnd.After(end) {
+			end = slotEnd
+		}
+	}
+
+	event := &ical.Event{
+		UID:         fmt.Sprintf("%s@%s", booking.UUID, config.Config.AppName),
+		Summary:     booking.Purpose,
+		Description: fmt.Sprintf("Booked by %s for %d attendees", booking.UserName, booking.Attendees),
+		Location:    booking.Room.Name,
+		Status:      ical.StatusConfirmed,
+		Start:       start,
+		End:         end,
+		UpdatedAt:   time.Now(),
+	}
+	if feed.Scope == constants.CalendarFeedScopeUser {
+		event.Summary = fmt.Sprintf("%s: %s", booking.Room.Name, booking.Purpose)
+	}
+	if booking.Status == constants.BookingPending {
+		event.Status = ical.StatusTentative
+	}
+	if booking.UpdatedAt != nil {
+		event.UpdatedAt = *booking.UpdatedAt
+	}
+
+	return event, nil
+}
+
+// Render writes the feed as an iCalendar document once token proves the URL
+// was issued for it.
+func (c *CalendarFeedService) Render(ctx context.Context, uuid, token string) ([]byte, error) {
+	feed, err := c.repository.GetCalendarFeed().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if !util.VerifySignature(config.Config.SignatureKey, token, feed.UUID.String(), feed.Secret) {
+		return nil, errCalendarFeed.ErrCalendarFeedInvalidToken
+	}
+
+	today := util.TruncateToDate(time.Now())
+	startDate := today.AddDate(0, 0, -config.Config.CalendarFeed.PastDays)
+	endDate := today.AddDate(0, 0, config.Config.CalendarFeed.FutureDays)
+
+	var (
+		bookings []models.Booking
+		name     string
+	)
+	if feed.Scope == constants.CalendarFeedScopeRoom {
+		// The room of a room feed can have been deleted since.
+		if feed.Room == nil {
+			return nil, errCalendarFeed.ErrCalendarFeedNotFound
+		}
+
+		name = feed.Room.Name
+		bookings, err = c.repository.GetBooking().FindAllActiveByRoomIDAndDateRange(ctx, feed.Room.ID, startDate, endDate)
+	} else {
+		if feed.UserID == nil {
+			return nil, errCalendarFeed.ErrCalendarFeedNotFound
+		}
+
+		name = "My room bookings"
+		bookings, err = c.repository.GetBooking().FindAllActiveByUserIDAndDateRange(ctx, *feed.UserID, startDate, endDate)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := ical.Calendar{
+		ProductID: fmt.Sprintf("-//%s//Room Bookings//EN", config.Config.AppName),
+		Name:      name,
+		Events:    make([]ical.Event, 0, len(bookings)),
+	}
+	for _, booking := range bookings {
+		if len(booking.BookingSchedules) == 0 {
+			continue
+		}
+
+		event, err := c.toEvent(feed, &booking)
+		if err != nil {
+			return nil, err
+		}
+		calendar.Events = append(calendar.Events, *event)
+	}
+
+	return calendar.Bytes(), nil
+}
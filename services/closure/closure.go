@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	clients "room-service/clients/user"
+	"room-service/constants"
+	errClosure "room-service/constants/error/closure"
+	errRoom "room-service/constants/error/room"
+	errRoomSchedule "room-service/constants/error/roomSchedule"
+	"room-service/domain/dto"
+	"room-service/domain/models"
+	"room-service/repositories"
+	bookingService "room-service/services/booking"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ClosureService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IClosureService interface {
+	GetAll(context.Context) ([]dto.ClosureResponse, error)
+	GetByUUID(context.Context, string) (*dto.ClosureResponse, error)
+	Create(context.Context, *dto.ClosureRequest) (*dto.ClosureResultResponse, error)
+	Update(context.Context, string, *dto.ClosureRequest) (*dto.ClosureResultResponse, error)
+	Delete(context.Context, string) error
+}
+
+func NewClosureService(repository repositories.IRepositoryRegistry) IClosureService {
+	return &ClosureService{repository: repository}
+}
+
+func (c *ClosureService) toClosureResponse(closure *models.Closure) dto.ClosureResponse {
+	response := dto.ClosureResponse{
+		UUID:      closure.UUID,
+		Library:   closure.Library,
+		StartDate: closure.StartDate.Format(time.DateOnly),
+		EndDate:   closure.EndDate.Format(time.DateOnly),
+		Reason:    closure.Reason,
+		CreatedAt: *closure.CreatedAt,
+		UpdatedAt: *closure.UpdatedAt,
+	}
+
+	if closure.Room != nil {
+		response.RoomID = &closure.Room.UUID
+		response.RoomName = closure.Room.Name
+	}
+
+	return response
+}
+
+func (c *ClosureService) GetAll(ctx context.Context) ([]dto.ClosureResponse, error) {
+	library, _ := ctx.Value(constants.Library).(string)
+	closures, err := c.repository.GetClosure().FindAll(ctx, library)
+	if err != nil {
+		return nil, err
+	}
+
+	closureResults := make([]dto.ClosureResponse, 0, len(closures))
+	for _, closure := range closures {
+		closureResults = append(closureResults, c.toClosureResponse(&closure))
+	}
+
+	return closureResults, nil
+}
+
+func (c *ClosureService) findByUUID(ctx context.Context, uuid string) (*models.Closure, error) {
+	closure, err := c.repository.GetClosure().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	library, _ := ctx.Value(constants.Library).(string)
+	if library != "" && closure.Library != library {
+		return nil, errClosure.ErrClosureNotFound
+	}
+
+	return closure, nil
+}
+
+func (c *ClosureService) GetByUUID(ctx context.Context, uuid string) (*dto.ClosureResponse, error) {
+	closure, err := c.findByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := c.toClosureResponse(closure)
+	return &response, nil
+}
+
+// toClosure validates the request and resolves its room, if any, within the
+// caller's library.
+func (c *ClosureService) toClosure(ctx context.Context, request *dto.ClosureRequest) (*models.Closure, error) {
+	startDate, err := time.Parse(time.DateOnly, request.StartDate)
+	if err != nil {
+		return nil, errRoomSchedule.ErrInvalidDateRange
+	}
+
+	endDate, err := time.Parse(time.DateOnly, request.EndDate)
+	if err != nil {
+		return nil, errRoomSchedule.ErrInvalidDateRange
+	}
+
+	if endDate.Before(startDate) {
+		return nil, errRoomSchedule.ErrInvalidDateRange
+	}
+
+	library, _ := ctx.Value(constants.Library).(string)
+	closure := &models.Closure{
+		Library:   library,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Reason:    request.Reason,
+	}
+
+	if request.RoomID != "" {
+		room, err := c.repository.GetRoom().FindByUUID(ctx, request.RoomID)
+		if err != nil {
+			return nil, err
+		}
+
+		if library != "" && room.Library != library {
+			return nil, errRoom.ErrRoomNotFound
+		}
+
+		closure.Library = room.Library
+		closure.RoomID = &room.ID
+	}
+
+	return closure, nil
+}
+
+// apply blocks every schedule the closure covers. Bookings holding any of
+// those slots are cancelled as a whole: their slots inside the closure are
+// blocked and the rest go back to Available.
+func (c *ClosureService) apply(ctx context.Context, tx *gorm.DB, closure *models.Closure) (*dto.ClosureResultResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	roomSchedules, err := c.repository.GetRoomSchedule().FindAllByDateRangeForUpdate(ctx, tx, closure.Library, closure.RoomID, closure.StartDate, closure.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.ClosureResultResponse{
+		CancelledBookings: make([]dto.ClosureBookingResponse, 0),
+	}
+	reason := fmt.Sprintf("Closed: %s", closure.Reason)
+	isHandled := make(map[uint]bool)
+	for _, roomSchedule := range roomSchedules {
+		if isHandled[roomSchedule.ID] {
+			continue
+		}
+
+		switch {
+		case roomSchedule.Status == constants.Available || roomSchedule.Status == constants.Held:
+			err = roomSchedule.TransitionTo(constants.Blocked)
+			if err != nil {
+				return nil, err
+			}
+
+			err = c.repository.GetRoomSchedule().UpdateStatus(ctx, tx, roomSchedule.Status, roomSchedule.UUID.String())
+			if err != nil {
+				return nil, err
+			}
+			result.BlockedSchedules++
+		case roomSchedule.Status.IsBooked() && roomSchedule.Status != constants.CheckedIn:
+			activeBooking, err := c.repository.GetBooking().FindActiveByRoomScheduleID(ctx, tx, roomSchedule.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			booking, err := c.repository.GetBooking().FindByUUIDForUpdate(ctx, tx, activeBooking.UUID.String())
+			if err != nil {
+				return nil, err
+			}
+
+			err = bookingService.NewBookingService(c.repository).LockSchedules(ctx, tx, booking)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, item := range booking.BookingSchedules {
+				isHandled[item.RoomScheduleID] = true
+				status := constants.Available
+				if closure.Covers(item.RoomSchedule.RoomID, item.RoomSchedule.Date) {
+					status = constants.Blocked
+					result.BlockedSchedules++
+				}
+
+				err = item.RoomSchedule.TransitionTo(status)
+				if err != nil {
+					return nil, err
+				}
+
+				err = c.repository.GetRoomSchedule().UpdateStatus(ctx, tx, item.RoomSchedule.Status, item.RoomSchedule.UUID.String())
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			err = c.repository.GetBooking().ReleaseSchedules(ctx, tx, booking.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			err = c.repository.GetBooking().Cancel(ctx, tx, booking.UUID.String(), user.UUID, reason)
+			if err != nil {
+				return nil, err
+			}
+
+			result.CancelledBookings = append(result.CancelledBookings, dto.ClosureBookingResponse{
+				UUID:     booking.UUID,
+				UserID:   booking.UserID,
+				UserName: booking.UserName,
+				RoomName: booking.Room.Name,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func (c *ClosureService) Create(ctx context.Context, request *dto.ClosureRequest) (*dto.ClosureResultResponse, error) {
+	closure, err := c.toClosure(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *dto.ClosureResultResponse
+	err = c.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		closure, err = c.repository.GetClosure().Create(ctx, tx, closure)
+		if err != nil {
+			return err
+		}
+
+		result, err = c.apply(ctx, tx, closure)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.GetByUUID(ctx, closure.UUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	result.Closure = *response
+	return result, nil
+}
+
+// Update moves or resizes a closure and applies it to the slots it now
+// covers. Slots it no longer covers stay blocked until staff reopen them.
+func (c *ClosureService) Update(ctx context.Context, uuid string, request *dto.ClosureRequest) (*dto.ClosureResultResponse, error) {
+	_, err := c.findByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	closure, err := c.toClosure(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *dto.ClosureResultResponse
+	err = c.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		err = c.repository.GetClosure().Update(ctx, tx, uuid, closure)
+		if err != nil {
+			return err
+		}
+
+		result, err = c.apply(ctx, tx, closure)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.GetByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Closure = *response
+	return result, nil
+}
+
+// Delete removes the closure. Slots it blocked stay blocked until staff
+// reopen them, since they cannot be told apart from maintenance blocks.
+func (c *ClosureService) Delete(ctx context.Context, uuid string) error {
+	_, err := c.findByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return c.repository.GetClosure().Delete(ctx, uuid)
+}
@@ -0,0 +1,311 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	clients "room-service/clients/user"
+	"room-service/config"
+	"room-service/constants"
+	errPenalty "room-service/constants/error/penalty"
+	"room-service/domain/dto"
+	"room-service/domain/models"
+	"room-service/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PenaltyService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IPenaltyService interface {
+	GetMine(context.Context) (*dto.PenaltyStatusResponse, error)
+	GetStrikes(context.Context, *dto.PenaltyRequestParam) ([]dto.StrikeResponse, error)
+	ClearStrike(context.Context, string) (*dto.StrikeResponse, error)
+	GetSuspensions(context.Context, *dto.PenaltyRequestParam) ([]dto.SuspensionResponse, error)
+	LiftSuspension(context.Context, string) (*dto.SuspensionResponse, error)
+	AddStrike(context.Context, *gorm.DB, *models.Booking, constants.StrikeReason) error
+	CheckSuspension(context.Context, *gorm.DB, uuid.UUID) error
+}
+
+func NewPenaltyService(repository repositories.IRepositoryRegistry) IPenaltyService {
+	return &PenaltyService{repository: repository}
+}
+
+func (p *PenaltyService) toStrikeResponse(strike *models.Strike) dto.StrikeResponse {
+	response := dto.StrikeResponse{
+		UUID:      strike.UUID,
+		UserID:    strike.UserID,
+		UserName:  strike.UserName,
+		Reason:    strike.Reason,
+		ClearedAt: strike.ClearedAt,
+		ClearedBy: strike.ClearedBy,
+		CreatedAt: strike.CreatedAt,
+	}
+
+	if strike.Booking != nil {
+		response.BookingID = &strike.Booking.UUID
+	}
+
+	return response
+}
+
+func (p *PenaltyService) toStrikeResponses(strikes []models.Strike) []dto.StrikeResponse {
+	strikeResults := make([]dto.StrikeResponse, 0, len(strikes))
+	for _, strike := range strikes {
+		strikeResults = append(strikeResults, p.toStrikeResponse(&strike))
+	}
+
+	return strikeResults
+}
+
+func (p *PenaltyService) toSuspensionResponse(suspension *models.Suspension) dto.SuspensionResponse {
+	return dto.SuspensionResponse{
+		UUID:      suspension.UUID,
+		UserID:    suspension.UserID,
+		UserName:  suspension.UserName,
+		Reason:    suspension.Reason,
+		EndsAt:    suspension.EndsAt,
+		IsActive:  suspension.IsActive(time.Now()),
+		LiftedAt:  suspension.LiftedAt,
+		LiftedBy:  suspension.LiftedBy,
+		CreatedAt: suspension.CreatedAt,
+	}
+}
+
+func (p *PenaltyService) parseUserID(param *dto.PenaltyRequestParam) *uuid.UUID {
+	if param.UserID == "" {
+		return nil
+	}
+
+	userID := uuid.MustParse(param.UserID)
+	return &userID
+}
+
+// strikeWindowStart returns the time from which strikes count towards a
+// suspension. Strikes that already led to a suspension do not count again.
+func (p *PenaltyService) strikeWindowStart(ctx context.Context, tx *gorm.DB, userID uuid.UUID, now time.Time) (time.Time, error) {
+	since := now.AddDate(0, 0, -config.Config.Penalty.StrikeWindowDays)
+	last, err := p.repository.GetPenalty().FindLastSuspension(ctx, tx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if last != nil && last.CreatedAt != nil && last.CreatedAt.After(since) {
+		since = *last.CreatedAt
+	}
+
+	return since, nil
+}
+
+func (p *PenaltyService) GetMine(ctx context.Context) (*dto.PenaltyStatusResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	now := time.Now()
+	tx := p.repository.GetTx()
+
+	since, err := p.strikeWindowStart(ctx, tx, user.UUID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := p.repository.GetPenalty().CountActiveStrikes(ctx, tx, user.UUID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	strikes, err := p.repository.GetPenalty().FindAllStrikes(ctx, "", &user.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.PenaltyStatusResponse{
+		ActiveStrikes: int(active),
+		Threshold:     config.Config.Penalty.StrikeThreshold,
+		Strikes:       p.toStrikeResponses(strikes),
+	}
+
+	suspension, err := p.repository.GetPenalty().FindActiveSuspension(ctx, tx, user.UUID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if suspension != nil {
+		suspensionResult := p.toSuspensionResponse(suspension)
+		response.Suspension = &suspensionResult
+	}
+
+	return response, nil
+}
+
+func (p *PenaltyService) GetStrikes(ctx context.Context, param *dto.PenaltyRequestParam) ([]dto.StrikeResponse, error) {
+	library, _ := ctx.Value(constants.Library).(string)
+	strikes, err := p.repository.GetPenalty().FindAllStrikes(ctx, library, p.parseUserID(param))
+	if err != nil {
+		return nil, err
+	}
+
+	return p.toStrikeResponses(strikes), nil
+}
+
+// ClearStrike stops the strike from counting towards a suspension. A
+// suspension it already led to stays in place until it is lifted.
+func (p *PenaltyService) ClearStrike(ctx context.Context, uuid string) (*dto.StrikeResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	library, _ := ctx.Value(constants.Library).(string)
+	var strike *models.Strike
+	err := p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		var err error
+		strike, err = p.repository.GetPenalty().FindStrikeByUUIDForUpdate(ctx, tx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if library != "" && strike.Library != library {
+			return errPenalty.ErrStrikeNotFound
+		}
+
+		if strike.ClearedAt != nil {
+			return errPenalty.ErrStrikeAlreadyCleared
+		}
+
+		err = p.repository.GetPenalty().ClearStrike(ctx, tx, uuid, user.UUID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		strike.ClearedAt = &now
+		strike.ClearedBy = &user.UUID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := p.toStrikeResponse(strike)
+	return &response, nil
+}
+
+func (p *PenaltyService) GetSuspensions(ctx context.Context, param *dto.PenaltyRequestParam) ([]dto.SuspensionResponse, error) {
+	library, _ := ctx.Value(constants.Library).(string)
+	suspensions, err := p.repository.GetPenalty().FindAllSuspensions(ctx, library, p.parseUserID(param))
+	if err != nil {
+		return nil, err
+	}
+
+	suspensionResults := make([]dto.SuspensionResponse, 0, len(suspensions))
+	for _, suspension := range suspensions {
+		suspensionResults = append(suspensionResults, p.toSuspensionResponse(&suspension))
+	}
+
+	return suspensionResults, nil
+}
+
+// LiftSuspension lets the user book again before the suspension runs out.
+func (p *PenaltyService) LiftSuspension(ctx context.Context, uuid string) (*dto.SuspensionResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	library, _ := ctx.Value(constants.Library).(string)
+	var suspension *models.Suspension
+	err := p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		var err error
+		suspension, err = p.repository.GetPenalty().FindSuspensionByUUIDForUpdate(ctx, tx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if library != "" && suspension.Library != library {
+			return errPenalty.ErrSuspensionNotFound
+		}
+
+		if suspension.LiftedAt != nil {
+			return errPenalty.ErrSuspensionAlreadyLifted
+		}
+
+		err = p.repository.GetPenalty().LiftSuspension(ctx, tx, uuid, user.UUID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		suspension.LiftedAt = &now
+		suspension.LiftedBy = &user.UUID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := p.toSuspensionResponse(suspension)
+	return &response, nil
+}
+
+// AddStrike records a strike against the owner of the booking, suspending
+// them once their strikes within the window reach the threshold. A zero
+// threshold records strikes without ever suspending.
+func (p *PenaltyService) AddStrike(ctx context.Context, tx *gorm.DB, booking *models.Booking, reason constants.StrikeReason) error {
+	err := p.repository.GetBooking().LockUser(ctx, tx, booking.UserID)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.repository.GetPenalty().CreateStrike(ctx, tx, &models.Strike{
+		UserID:    booking.UserID,
+		UserName:  booking.UserName,
+		Library:   booking.Room.Library,
+		BookingID: &booking.ID,
+		Reason:    reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	penalty := config.Config.Penalty
+	if penalty.StrikeThreshold <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	suspension, err := p.repository.GetPenalty().FindActiveSuspension(ctx, tx, booking.UserID, now)
+	if err != nil || suspension != nil {
+		return err
+	}
+
+	since, err := p.strikeWindowStart(ctx, tx, booking.UserID, now)
+	if err != nil {
+		return err
+	}
+
+	strikes, err := p.repository.GetPenalty().CountActiveStrikes(ctx, tx, booking.UserID, since)
+	if err != nil {
+		return err
+	}
+
+	if strikes < int64(penalty.StrikeThreshold) {
+		return nil
+	}
+
+	_, err = p.repository.GetPenalty().CreateSuspension(ctx, tx, &models.Suspension{
+		UserID:   booking.UserID,
+		UserName: booking.UserName,
+		Library:  booking.Room.Library,
+		Reason:   fmt.Sprintf("%d strikes within %d days", strikes, penalty.StrikeWindowDays),
+		EndsAt:   now.AddDate(0, 0, penalty.SuspensionDays),
+	})
+	return err
+}
+
+// CheckSuspension fails when the user is suspended from booking.
+func (p *PenaltyService) CheckSuspension(ctx context.Context, tx *gorm.DB, userID uuid.UUID) error {
+	suspension, err := p.repository.GetPenalty().FindActiveSuspension(ctx, tx, userID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if suspension != nil {
+		return errPenalty.ErrUserSuspended
+	}
+
+	return nil
+}
@@ -3,9 +3,17 @@ package services
 import (
 	"room-service/common/gcs"
 	"room-service/repositories"
+	amenityService "room-service/services/amenity"
+	bookingService "room-service/services/booking"
+	bookingQuotaService "room-service/services/bookingQuota"
+	calendarFeedService "room-service/services/calendarFeed"
+	closureService "room-service/services/closure"
+	penaltyService "room-service/services/penalty"
 	roomService "room-service/services/room"
 	roomScheduleService "room-service/services/roomSchedule"
+	roomSlotTemplateService "room-service/services/roomSlotTemplate"
 	timeService "room-service/services/time"
+	waitlistService "room-service/services/waitlist"
 )
 
 type Registry struct {
@@ -14,15 +22,47 @@ type Registry struct {
 }
 
 type IServiceRegistry interface {
+	GetAmenity() amenityService.IAmenityService
+	GetBooking() bookingService.IBookingService
+	GetBookingQuota() bookingQuotaService.IBookingQuotaService
+	GetCalendarFeed() calendarFeedService.ICalendarFeedService
+	GetClosure() closureService.IClosureService
+	GetPenalty() penaltyService.IPenaltyService
 	GetRoom() roomService.IRoomService
 	GetRoomSchedule() roomScheduleService.IRoomScheduleService
+	GetRoomSlotTemplate() roomSlotTemplateService.IRoomSlotTemplateService
 	GetTime() timeService.ITimeService
+	GetWaitlist() waitlistService.IWaitlistService
 }
 
 func NewServiceRegistry(repository repositories.IRepositoryRegistry, gcs gcs.IGCSClient) IServiceRegistry {
 	return &Registry{repository: repository, gcs: gcs}
 }
 
+func (r *Registry) GetAmenity() amenityService.IAmenityService {
+	return amenityService.NewAmenityService(r.repository)
+}
+
+func (r *Registry) GetBooking() bookingService.IBookingService {
+	return bookingService.NewBookingService(r.repository)
+}
+
+func (r *Registry) GetBookingQuota() bookingQuotaService.IBookingQuotaService {
+	return bookingQuotaService.NewBookingQuotaService(r.repository)
+}
+
+func (r *Registry) GetCalendarFeed() calendarFeedService.ICalendarFeedService {
+	return calendarFeedService.NewCalendarFeedService(r.repository)
+}
+
+func (r *Registry) GetClosure() closureService.IClosureService {
+	return closureService.NewClosureService(r.repository)
+}
+
+func (r *Registry) GetPenalty() penaltyService.IPenaltyService {
+	return penaltyService.NewPenaltyService(r.repository)
+}
+
 func (r *Registry) GetRoom() roomService.IRoomService {
 	return roomService.NewRoomService(r.repository, r.gcs)
 }
@@ -31,6 +71,14 @@ func (r *Registry) GetRoomSchedule() roomScheduleService.IRoomScheduleService {
 	return roomScheduleService.NewRoomScheduleService(r.repository)
 }
 
+func (r *Registry) GetRoomSlotTemplate() roomSlotTemplateService.IRoomSlotTemplateService {
+	return roomSlotTemplateService.NewRoomSlotTemplateService(r.repository)
+}
+
 func (r *Registry) GetTime() timeService.ITimeService {
 	return timeService.NewTimeService(r.repository)
 }
+
+func (r *Registry) GetWaitlist() waitlistService.IWaitlistService {
+	return waitlistService.NewWaitlistService(r.repository)
+}
@@ -7,13 +7,24 @@ import (
 	"io"
 	"mime/multipart"
 	"path"
+	errValidation "room-service/common/error"
 	"room-service/common/gcs"
+	"room-service/common/tabular"
 	"room-service/common/util"
+	"room-service/constants"
 	errConstant "room-service/constants/error"
+	errAmenity "room-service/constants/error/amenity"
+	errRoom "room-service/constants/error/room"
 	"room-service/domain/dto"
 	"room-service/domain/models"
 	"room-service/repositories"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
 type RoomService struct {
@@ -23,11 +34,15 @@ type RoomService struct {
 
 type IRoomService interface {
 	GetAllWithPagination(context.Context, *dto.RoomRequestParam) (*util.PaginationResult, error)
-	GetAllWithoutPagination(context.Context) ([]dto.RoomResponse, error)
+	GetAllWithoutPagination(context.Context, *dto.RoomFilterParam) ([]dto.RoomResponse, error)
 	GetByUUID(context.Context, string) (*dto.RoomResponse, error)
 	Create(context.Context, *dto.RoomRequest) (*dto.RoomResponse, error)
 	Update(context.Context, string, *dto.RoomRequest) (*dto.RoomResponse, error)
 	Delete(context.Context, string) error
+	GetCheckInToken(context.Context, string) (*dto.RoomCheckInTokenResponse, error)
+	RotateCheckInToken(context.Context, string) (*dto.RoomCheckInTokenResponse, error)
+	Import(context.Context, *dto.RoomImportRequest) (*dto.RoomImportResponse, error)
+	Export(context.Context, *dto.RoomExportParam) (*dto.RoomExportFile, error)
 }
 
 func NewRoomService(repository repositories.IRepositoryRegistry, gcs gcs.IGCSClient) IRoomService {
@@ -35,7 +50,8 @@ func NewRoomService(repository repositories.IRepositoryRegistry, gcs gcs.IGCSCli
 }
 
 func (r *RoomService) GetAllWithPagination(ctx context.Context, param *dto.RoomRequestParam) (*util.PaginationResult, error) {
-	rooms, total, err := r.repository.GetRoom().FindAllWithPagination(ctx, param)
+	library, _ := ctx.Value(constants.Library).(string)
+	rooms, total, err := r.repository.GetRoom().FindAllWithPagination(ctx, param, library)
 	if err != nil {
 		return nil, err
 	}
@@ -43,14 +59,17 @@ func (r *RoomService) GetAllWithPagination(ctx context.Context, param *dto.RoomR
 	roomResults := make([]dto.RoomResponse, 0, len(rooms))
 	for _, room := range rooms {
 		roomResults = append(roomResults, dto.RoomResponse{
-			UUID:        room.UUID,
-			Code:        room.Code,
-			Name:        room.Name,
-			Capacity:    room.Capacity,
-			Description: room.Description,
-			Image:       room.Image,
-			CreatedAt:   *room.CreatedAt,
-			UpdatedAt:   *room.UpdatedAt,
+			UUID:             room.UUID,
+			Library:          room.Library,
+			Code:             room.Code,
+			Name:             room.Name,
+			Capacity:         room.Capacity,
+			Description:      room.Description,
+			RequiresApproval: room.RequiresApproval,
+			Image:            room.Image,
+			Amenities:        r.toAmenityResponses(room.Amenities),
+			CreatedAt:        *room.CreatedAt,
+			UpdatedAt:        *room.UpdatedAt,
 		})
 	}
 
@@ -65,8 +84,9 @@ func (r *RoomService) GetAllWithPagination(ctx context.Context, param *dto.RoomR
 	return &response, nil
 }
 
-func (r *RoomService) GetAllWithoutPagination(ctx context.Context) ([]dto.RoomResponse, error) {
-	rooms, err := r.repository.GetRoom().FindAllWithoutPagination(ctx)
+func (r *RoomService) GetAllWithoutPagination(ctx context.Context, param *dto.RoomFilterParam) ([]dto.RoomResponse, error) {
+	library, _ := ctx.Value(constants.Library).(string)
+	rooms, err := r.repository.GetRoom().FindAllWithoutPagination(ctx, library, param)
 	if err != nil {
 		return nil, err
 	}
@@ -74,32 +94,85 @@ func (r *RoomService) GetAllWithoutPagination(ctx context.Context) ([]dto.RoomRe
 	roomResults := make([]dto.RoomResponse, 0, len(rooms))
 	for _, room := range rooms {
 		roomResults = append(roomResults, dto.RoomResponse{
-			UUID:        room.UUID,
-			Name:        room.Name,
-			Capacity:    room.Capacity,
-			Description: room.Description,
-			Image:       room.Image,
+			UUID:             room.UUID,
+			Library:          room.Library,
+			Name:             room.Name,
+			Capacity:         room.Capacity,
+			Description:      room.Description,
+			RequiresApproval: room.RequiresApproval,
+			Image:            room.Image,
+			Amenities:        r.toAmenityResponses(room.Amenities),
 		})
 	}
 
 	return roomResults, nil
 }
 
-func (r *RoomService) GetByUUID(ctx context.Context, uuid string) (*dto.RoomResponse, error) {
+func (r *RoomService) toAmenityResponses(amenities []models.Amenity) []dto.AmenityResponse {
+	amenityResults := make([]dto.AmenityResponse, 0, len(amenities))
+	for _, amenity := range amenities {
+		amenityResults = append(amenityResults, dto.AmenityResponse{
+			UUID:        amenity.UUID,
+			Name:        amenity.Name,
+			Description: amenity.Description,
+		})
+	}
+
+	return amenityResults
+}
+
+// findAmenities resolves the requested amenities, failing when any of them
+// does not exist.
+func (r *RoomService) findAmenities(ctx context.Context, uuids []string) ([]models.Amenity, error) {
+	if len(uuids) == 0 {
+		return []models.Amenity{}, nil
+	}
+
+	amenities, err := r.repository.GetAmenity().FindAllByUUIDs(ctx, uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(amenities) != len(uuids) {
+		return nil, errAmenity.ErrAmenityNotFound
+	}
+
+	return amenities, nil
+}
+
+// findByUUID hides rooms of other libraries as if they did not exist.
+func (r *RoomService) findByUUID(ctx context.Context, uuid string) (*models.Room, error) {
 	room, err := r.repository.GetRoom().FindByUUID(ctx, uuid)
 	if err != nil {
 		return nil, err
 	}
 
+	library, _ := ctx.Value(constants.Library).(string)
+	if library != "" && room.Library != library {
+		return nil, errRoom.ErrRoomNotFound
+	}
+
+	return room, nil
+}
+
+func (r *RoomService) GetByUUID(ctx context.Context, uuid string) (*dto.RoomResponse, error) {
+	room, err := r.findByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
 	roomResult := dto.RoomResponse{
-		UUID:        room.UUID,
-		Code:        room.Code,
-		Name:        room.Name,
-		Capacity:    room.Capacity,
-		Description: room.Description,
-		Image:       room.Image,
-		CreatedAt:   *room.CreatedAt,
-		UpdatedAt:   *room.UpdatedAt,
+		UUID:             room.UUID,
+		Library:          room.Library,
+		Code:             room.Code,
+		Name:             room.Name,
+		Capacity:         room.Capacity,
+		Description:      room.Description,
+		RequiresApproval: room.RequiresApproval,
+		Image:            room.Image,
+		Amenities:        r.toAmenityResponses(room.Amenities),
+		CreatedAt:        *room.CreatedAt,
+		UpdatedAt:        *room.UpdatedAt,
 	}
 
 	return &roomResult, nil
@@ -160,41 +233,69 @@ func (r *RoomService) uploadImage(ctx context.Context, images []multipart.FileHe
 }
 
 func (r *RoomService) Create(ctx context.Context, request *dto.RoomRequest) (*dto.RoomResponse, error) {
+	amenities, err := r.findAmenities(ctx, request.AmenityIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	imageUrl, err := r.uploadImage(ctx, request.Image)
 	if err != nil {
 		return nil, err
 	}
 
-	room, err := r.repository.GetRoom().Create(ctx, &models.Room{
-		Code:        request.Code,
-		Name:        request.Name,
-		Capacity:    request.Capacity,
-		Description: request.Description,
-		Image:       imageUrl,
+	library, _ := ctx.Value(constants.Library).(string)
+	var room *models.Room
+	err = r.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		room, err = r.repository.GetRoom().Create(ctx, tx, &models.Room{
+			Library:          library,
+			Code:             request.Code,
+			Name:             request.Name,
+			Capacity:         request.Capacity,
+			Description:      request.Description,
+			RequiresApproval: request.RequiresApproval,
+			Image:            imageUrl,
+		})
+		if err != nil {
+			return err
+		}
+
+		return r.repository.GetRoom().ReplaceAmenities(ctx, tx, room, amenities)
 	})
 	if err != nil {
 		return nil, err
 	}
+	room.Amenities = amenities
 
 	response := &dto.RoomResponse{
-		UUID:        room.UUID,
-		Code:        room.Code,
-		Name:        room.Name,
-		Capacity:    room.Capacity,
-		Description: room.Description,
-		Image:       room.Image,
-		CreatedAt:   *room.CreatedAt,
-		UpdatedAt:   *room.UpdatedAt,
+		UUID:             room.UUID,
+		Library:          room.Library,
+		Code:             room.Code,
+		Name:             room.Name,
+		Capacity:         room.Capacity,
+		Description:      room.Description,
+		RequiresApproval: room.RequiresApproval,
+		Image:            room.Image,
+		Amenities:        r.toAmenityResponses(room.Amenities),
+		CreatedAt:        *room.CreatedAt,
+		UpdatedAt:        *room.UpdatedAt,
 	}
 	return response, nil
 }
 
 func (r *RoomService) Update(ctx context.Context, uuid string, request *dto.RoomRequest) (*dto.RoomResponse, error) {
-	room, err := r.repository.GetRoom().FindByUUID(ctx, uuid)
+	room, err := r.findByUUID(ctx, uuid)
 	if err != nil {
 		return nil, err
 	}
 
+	amenities := room.Amenities
+	if request.AmenityIDs != nil {
+		amenities, err = r.findAmenities(ctx, request.AmenityIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var imageUrl []string
 	if request.Image == nil {
 		imageUrl = room.Image
@@ -205,31 +306,30 @@ func (r *RoomService) Update(ctx context.Context, uuid string, request *dto.Room
 		}
 	}
 
-	roomResult, err := r.repository.GetRoom().Update(ctx, uuid, &models.Room{
-		Code:        request.Code,
-		Name:        request.Name,
-		Capacity:    request.Capacity,
-		Description: request.Description,
-		Image:       imageUrl,
+	err = r.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		err := r.repository.GetRoom().Update(ctx, tx, uuid, &models.Room{
+			Code:             request.Code,
+			Name:             request.Name,
+			Capacity:         request.Capacity,
+			Description:      request.Description,
+			RequiresApproval: request.RequiresApproval,
+			Image:            imageUrl,
+		})
+		if err != nil {
+			return err
+		}
+
+		return r.repository.GetRoom().ReplaceAmenities(ctx, tx, room, amenities)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &dto.RoomResponse{
-		UUID:        roomResult.UUID,
-		Code:        roomResult.Code,
-		Name:        roomResult.Name,
-		Capacity:    roomResult.Capacity,
-		Description: roomResult.Description,
-		Image:       roomResult.Image,
-		CreatedAt:   *roomResult.CreatedAt,
-		UpdatedAt:   *roomResult.UpdatedAt,
-	}, nil
+	return r.GetByUUID(ctx, uuid)
 }
 
 func (r *RoomService) Delete(ctx context.Context, uuid string) error {
-	_, err := r.repository.GetRoom().FindByUUID(ctx, uuid)
+	_, err := r.findByUUID(ctx, uuid)
 	if err != nil {
 		return err
 	}
@@ -241,3 +341,315 @@ func (r *RoomService) Delete(ctx context.Context, uuid string) error {
 
 	return nil
 }
+
+// GetCheckInToken returns the token printed in the room's check-in QR code,
+// issuing one the first time it is asked for.
+func (r *RoomService) GetCheckInToken(ctx context.Context, uuid string) (*dto.RoomCheckInTokenResponse, error) {
+	room, err := r.findByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if room.CheckInToken == "" {
+		return r.RotateCheckInToken(ctx, uuid)
+	}
+
+	return &dto.RoomCheckInTokenResponse{
+		RoomID:   room.UUID,
+		RoomName: room.Name,
+		Token:    room.CheckInToken,
+	}, nil
+}
+
+// RotateCheckInToken issues a new check-in token, invalidating printed QR
+// codes that carry the old one.
+func (r *RoomService) RotateCheckInToken(ctx context.Context, uuid string) (*dto.RoomCheckInTokenResponse, error) {
+	room, err := r.findByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := util.GenerateToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.repository.GetRoom().UpdateCheckInToken(ctx, uuid, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RoomCheckInTokenResponse{
+		RoomID:   room.UUID,
+		RoomName: room.Name,
+		Token:    token,
+	}, nil
+}
+
+// readRoomFile reads the rows of an uploaded room file, keyed by lower-cased
+// column name.
+func (r *RoomService) readRoomFile(file *multipart.FileHeader) ([]map[string]string, error) {
+	if file.Size > 5*1024*1024 {
+		return nil, errConstant.ErrSizeTooBig
+	}
+
+	format := tabular.FormatOf(file.Filename)
+	if format != tabular.FormatCSV && format != tabular.FormatXLSX {
+		return nil, errRoom.ErrRoomFileFormat
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, errRoom.ErrRoomFileUnreadable
+	}
+	defer reader.Close()
+
+	rows, err := tabular.Read(format, reader)
+	if err != nil {
+		return nil, errRoom.ErrRoomFileUnreadable
+	}
+
+	if len(rows) < 2 {
+		return nil, errRoom.ErrRoomFileEmpty
+	}
+
+	header := make([]string, 0, len(rows[0]))
+	columns := make(map[string]bool)
+	for _, column := range rows[0] {
+		column = strings.ToLower(strings.TrimSpace(column))
+		header = append(header, column)
+		columns[column] = true
+	}
+
+	for _, column := range []string{"code", "name", "capacity", "description"} {
+		if !columns[column] {
+			return nil, errRoom.ErrRoomFileMissingColumns
+		}
+	}
+
+	records := make([]map[string]string, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		record := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(row) {
+				record[column] = strings.TrimSpace(row[i])
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// parseRoomRecord turns a row of a room file into an import row, collecting
+// every problem with it rather than stopping at the first.
+func (r *RoomService) parseRoomRecord(record map[string]string, amenities map[string]models.Amenity) (*dto.RoomImportRow, []models.Amenity, []string) {
+	var problems []string
+	row := &dto.RoomImportRow{
+		Code:        record["code"],
+		Name:        record["name"],
+		Description: record["description"],
+	}
+
+	if record["capacity"] != "" {
+		capacity, err := strconv.Atoi(record["capacity"])
+		if err != nil {
+			problems = append(problems, "capacity must be a whole number")
+		}
+		row.Capacity = capacity
+	}
+
+	if value := record["requiresapproval"]; value != "" {
+		requiresApproval, err := strconv.ParseBool(value)
+		if err != nil {
+			problems = append(problems, "requiresApproval must be true or false")
+		}
+		row.RequiresApproval = requiresApproval
+	}
+
+	for _, image := range strings.Split(record["images"], "|") {
+		image = strings.TrimSpace(image)
+		if image != "" {
+			row.Images = append(row.Images, image)
+		}
+	}
+
+	roomAmenities := make([]models.Amenity, 0)
+	for _, name := range util.SplitList(record["amenities"]) {
+		amenity, ok := amenities[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("amenity %q not found", name))
+			continue
+		}
+		roomAmenities = append(roomAmenities, amenity)
+	}
+
+	err := validator.New().Struct(row)
+	if err != nil {
+		for _, item := range errValidation.ErrValidationResponse(err) {
+			problems = append(problems, item.Message)
+		}
+	}
+
+	return row, roomAmenities, problems
+}
+
+// Import creates or updates the library's rooms from a CSV or XLSX file,
+// matching rows to rooms by code. Every row is validated first and nothing is
+// written when any row is invalid or on a dry run. Updated rooms keep their
+// images when the row lists none. Callers without a library of their own
+// import into the library named in the request.
+func (r *RoomService) Import(ctx context.Context, request *dto.RoomImportRequest) (*dto.RoomImportResponse, error) {
+	library, _ := ctx.Value(constants.Library).(string)
+	if library == "" {
+		library = request.Library
+	}
+
+	if library == "" {
+		return nil, errRoom.ErrRoomLibraryRequired
+	}
+
+	records, err := r.readRoomFile(request.File)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms, err := r.repository.GetRoom().FindAllWithoutPagination(ctx, library, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	roomsByCode := make(map[string]models.Room, len(rooms))
+	for _, room := range rooms {
+		roomsByCode[room.Code] = room
+	}
+
+	allAmenities, err := r.repository.GetAmenity().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	amenitiesByName := make(map[string]models.Amenity, len(allAmenities))
+	for _, amenity := range allAmenities {
+		amenitiesByName[strings.ToLower(amenity.Name)] = amenity
+	}
+
+	type upsert struct {
+		room      models.Room
+		amenities []models.Amenity
+	}
+
+	response := &dto.RoomImportResponse{DryRun: request.DryRun}
+	upserts := make([]upsert, 0, len(records))
+	seenCodes := make(map[string]int)
+	for i, record := range records {
+		// The header is the first row of the file.
+		rowNumber := i + 2
+		row, roomAmenities, problems := r.parseRoomRecord(record, amenitiesByName)
+		if firstRow, ok := seenCodes[row.Code]; ok && row.Code != "" {
+			problems = append(problems, fmt.Sprintf("code %s is already used on row %d", row.Code, firstRow))
+		} else {
+			seenCodes[row.Code] = rowNumber
+		}
+
+		result := dto.RoomImportRowResult{Row: rowNumber, Code: row.Code}
+		if len(problems) > 0 {
+			result.Action = constants.RoomImportInvalid
+			result.Errors = problems
+			response.Invalid++
+			response.Rows = append(response.Rows, result)
+			continue
+		}
+
+		room, ok := roomsByCode[row.Code]
+		if ok {
+			result.Action = constants.RoomImportUpdate
+			response.Updated++
+		} else {
+			result.Action = constants.RoomImportCreate
+			response.Created++
+			room = models.Room{Library: library, Code: row.Code, Image: []string{}}
+		}
+
+		room.Name = row.Name
+		room.Capacity = row.Capacity
+		room.Description = row.Description
+		room.RequiresApproval = row.RequiresApproval
+		room.Amenities = nil
+		if len(row.Images) > 0 {
+			room.Image = row.Images
+		}
+
+		upserts = append(upserts, upsert{room: room, amenities: roomAmenities})
+		response.Rows = append(response.Rows, result)
+	}
+
+	if request.DryRun || response.Invalid > 0 {
+		return response, nil
+	}
+
+	err = r.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		for _, item := range upserts {
+			err := r.repository.GetRoom().Upsert(ctx, tx, &item.room, item.amenities)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response.Applied = true
+	return response, nil
+}
+
+// Export writes the library's rooms in the columns Import reads, so that an
+// export can be edited and imported again.
+func (r *RoomService) Export(ctx context.Context, param *dto.RoomExportParam) (*dto.RoomExportFile, error) {
+	format := param.Format
+	if format == "" {
+		format = tabular.FormatCSV
+	}
+
+	library, _ := ctx.Value(constants.Library).(string)
+	rooms, err := r.repository.GetRoom().FindAllWithoutPagination(ctx, library, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].Code < rooms[j].Code
+	})
+
+	rows := make([][]string, 0, len(rooms)+1)
+	rows = append(rows, constants.RoomFileColumns)
+	for _, room := range rooms {
+		amenities := make([]string, 0, len(room.Amenities))
+		for _, amenity := range room.Amenities {
+			amenities = append(amenities, amenity.Name)
+		}
+
+		rows = append(rows, []string{
+			room.Code,
+			room.Name,
+			strconv.Itoa(room.Capacity),
+			room.Description,
+			strconv.FormatBool(room.RequiresApproval),
+			strings.Join(room.Image, "|"),
+			strings.Join(amenities, ","),
+		})
+	}
+
+	data, err := tabular.Write(format, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RoomExportFile{
+		Name:        fmt.Sprintf("rooms-%s.%s", time.Now().Format("2006-01-02"), format),
+		ContentType: tabular.ContentType(format),
+		Data:        data,
+	}, nil
+}
@@ -3,15 +3,23 @@ package services
 import (
 	"context"
 	"fmt"
+	clients "room-service/clients/user"
 	"room-service/common/util"
+	"room-service/config"
 	"room-service/constants"
+	errClosure "room-service/constants/error/closure"
+	errRoom "room-service/constants/error/room"
 	errRoomSchedule "room-service/constants/error/roomSchedule"
+	errTime "room-service/constants/error/time"
 	"room-service/domain/dto"
 	"room-service/domain/models"
 	"room-service/repositories"
+	penaltyService "room-service/services/penalty"
+	waitlistService "room-service/services/waitlist"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type RoomScheduleService struct {
@@ -22,11 +30,18 @@ type IRoomScheduleService interface {
 	GetAllWithPagination(context.Context, *dto.RoomScheduleRequestParam) (*util.PaginationResult, error)
 	GetAllByRoomIDAndDate(context.Context, string, string) ([]dto.RoomScheduleForBookingResponse, error)
 	GetByUUID(context.Context, string) (*dto.RoomScheduleResponse, error)
+	SearchAvailability(context.Context, *dto.AvailabilitySearchParam) ([]dto.AvailableRoomResponse, error)
+	GetCalendar(context.Context, *dto.CalendarRequestParam) (*dto.CalendarResponse, error)
 	GenerateScheduleForOneMonth(context.Context, *dto.GenerateRoomScheduleForOneMostRequest) error
+	GenerateSchedule(context.Context, *dto.GenerateRoomScheduleRequest) (*dto.GenerateRoomScheduleResponse, error)
+	GenerateRollingWindow(context.Context, int) (int, error)
 	Create(context.Context, *dto.RoomScheduleRequest) error
 	Update(context.Context, string, *dto.UpdateRoomScheduleRequest) (*dto.RoomScheduleResponse, error)
-	UpdateStatus(context.Context, *dto.UpdateStatusRoomScheduleRequest) error
+	UpdateStatus(context.Context, string, *dto.UpdateStatusRoomScheduleRequest) (*dto.RoomScheduleResponse, error)
 	Delete(context.Context, string) error
+	Hold(context.Context, *dto.HoldRoomScheduleRequest) ([]dto.HeldRoomScheduleResponse, error)
+	Release(context.Context, *dto.HoldRoomScheduleRequest) error
+	ReleaseExpiredHolds(context.Context) (int, error)
 }
 
 func NewRoomScheduleService(repository repositories.IRepositoryRegistry) IRoomScheduleService {
@@ -39,7 +54,8 @@ func (r *RoomScheduleService) GetAllWithPagination(
 	ctx context.Context,
 	param *dto.RoomScheduleRequestParam,
 ) (*util.PaginationResult, error) {
-	roomSchedules, total, err := r.repository.GetRoomSchedule().FindAllWithPagination(ctx, param)
+	library, _ := ctx.Value(constants.Library).(string)
+	roomSchedules, total, err := r.repository.GetRoomSchedule().FindAllWithPagination(ctx, param, library)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +69,7 @@ func (r *RoomScheduleService) GetAllWithPagination(
 			Capacity:    schedule.Room.Capacity,
 			Description: schedule.Room.Description,
 			Status:      schedule.Status.GetStatusString(),
-			Time:        fmt.Sprintf("%s - %s", schedule.Time.StartTime, schedule.Time.EndTime),
+			Time:        fmt.Sprintf("%s - %s", util.FormatClock(schedule.Time.StartTime), util.FormatClock(schedule.Time.EndTime)),
 			CreatedAt:   *schedule.CreatedAt,
 			UpdatedAt:   *schedule.UpdatedAt,
 		})
@@ -98,12 +114,41 @@ func (r *RoomScheduleService) convertMonthName(inputDate string) string {
 	return formattedDate
 }
 
-func (r *RoomScheduleService) GetAllByRoomIDAndDate(ctx context.Context, uuid, date string) ([]dto.RoomScheduleForBookingResponse, error) {
+// findRoom hides rooms of other libraries as if they did not exist.
+func (r *RoomScheduleService) findRoom(ctx context.Context, uuid string) (*models.Room, error) {
 	room, err := r.repository.GetRoom().FindByUUID(ctx, uuid)
 	if err != nil {
 		return nil, err
 	}
 
+	library, _ := ctx.Value(constants.Library).(string)
+	if library != "" && room.Library != library {
+		return nil, errRoom.ErrRoomNotFound
+	}
+
+	return room, nil
+}
+
+func (r *RoomScheduleService) findByUUID(ctx context.Context, uuid string) (*models.RoomSchedule, error) {
+	roomSchedule, err := r.repository.GetRoomSchedule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	library, _ := ctx.Value(constants.Library).(string)
+	if library != "" && roomSchedule.Room.Library != library {
+		return nil, errRoomSchedule.ErrRoomScheduleNotFound
+	}
+
+	return roomSchedule, nil
+}
+
+func (r *RoomScheduleService) GetAllByRoomIDAndDate(ctx context.Context, uuid, date string) ([]dto.RoomScheduleForBookingResponse, error) {
+	room, err := r.findRoom(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
 	roomSchedules, err := r.repository.GetRoomSchedule().FindAllByRoomIDAndDate(ctx, int(room.ID), date)
 	if err != nil {
 		return nil, err
@@ -114,7 +159,8 @@ func (r *RoomScheduleService) GetAllByRoomIDAndDate(ctx context.Context, uuid, d
 		roomScheduleResults = append(roomScheduleResults, dto.RoomScheduleForBookingResponse{
 			UUID:        schedule.UUID,
 			Date:        r.convertMonthName(schedule.Date.Format(time.DateOnly)),
-			Time:        schedule.Time.StartTime,
+			Time:        util.FormatClock(schedule.Time.StartTime),
+			EndTime:     util.FormatClock(schedule.Time.EndTime),
 			Status:      schedule.Status.GetStatusString(),
 			Capacity:    schedule.Room.Capacity,
 			Description: schedule.Room.Description,
@@ -124,8 +170,174 @@ func (r *RoomScheduleService) GetAllByRoomIDAndDate(ctx context.Context, uuid, d
 	return roomScheduleResults, nil
 }
 
+// maxSearchDays bounds the date range of an availability search.
+const maxSearchDays = 31
+
+// SearchAvailability lists the rooms of the caller's library that have free
+// slots matching the search, each with those slots.
+func (r *RoomScheduleService) SearchAvailability(ctx context.Context, param *dto.AvailabilitySearchParam) ([]dto.AvailableRoomResponse, error) {
+	if param.EndDate == "" {
+		param.EndDate = param.StartDate
+	}
+
+	startDate, err := time.Parse(time.DateOnly, param.StartDate)
+	if err != nil {
+		return nil, errRoomSchedule.ErrInvalidDateRange
+	}
+
+	endDate, err := time.Parse(time.DateOnly, param.EndDate)
+	if err != nil {
+		return nil, errRoomSchedule.ErrInvalidDateRange
+	}
+
+	if endDate.Before(startDate) || endDate.Sub(startDate) > maxSearchDays*24*time.Hour {
+		return nil, errRoomSchedule.ErrInvalidDateRange
+	}
+
+	if param.StartTime != "" && param.EndTime != "" && param.StartTime >= param.EndTime {
+		return nil, errTime.ErrInvalidTimeRange
+	}
+
+	library, _ := ctx.Value(constants.Library).(string)
+	roomSchedules, err := r.repository.GetRoomSchedule().FindAllAvailable(ctx, library, param)
+	if err != nil {
+		return nil, err
+	}
+
+	roomResults := make([]dto.AvailableRoomResponse, 0)
+	for _, schedule := range roomSchedules {
+		last := len(roomResults) - 1
+		if last < 0 || roomResults[last].UUID != schedule.Room.UUID {
+			roomResults = append(roomResults, dto.AvailableRoomResponse{
+				UUID:     schedule.Room.UUID,
+				Library:  schedule.Room.Library,
+				Code:     schedule.Room.Code,
+				Name:     schedule.Room.Name,
+				Capacity: schedule.Room.Capacity,
+				Slots:    make([]dto.AvailableSlotResponse, 0),
+			})
+			last++
+		}
+
+		roomResults[last].Slots = append(roomResults[last].Slots, dto.AvailableSlotResponse{
+			UUID:      schedule.UUID,
+			Date:      schedule.Date.Format(time.DateOnly),
+			StartTime: util.FormatClock(schedule.Time.StartTime),
+			EndTime:   util.FormatClock(schedule.Time.EndTime),
+		})
+	}
+
+	return roomResults, nil
+}
+
+// calendarRange returns the first and last day of the week, starting on
+// Monday, or of the month that contains date.
+func (r *RoomScheduleService) calendarRange(date time.Time, view string) (time.Time, time.Time) {
+	if view == constants.CalendarViewMonth {
+		startDate := date.AddDate(0, 0, 1-date.Day())
+		return startDate, startDate.AddDate(0, 1, -1)
+	}
+
+	startDate := date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+	return startDate, startDate.AddDate(0, 0, 6)
+}
+
+// GetCalendar lays out a room's schedules as a grid of days by time slots.
+// Each day only lists the time slots the room's template opens on that
+// weekday, plus any slot already scheduled. Cells without a schedule are
+// marked Empty.
+func (r *RoomScheduleService) GetCalendar(ctx context.Context, param *dto.CalendarRequestParam) (*dto.CalendarResponse, error) {
+	room, err := r.findRoom(ctx, param.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse(time.DateOnly, param.Date)
+	if err != nil {
+		return nil, errRoomSchedule.ErrInvalidDateRange
+	}
+
+	view := param.View
+	if view == "" {
+		view = constants.CalendarViewWeek
+	}
+
+	startDate, endDate := r.calendarRange(date, view)
+	timeSlots, err := r.repository.GetTime().FindAll(ctx, room.Library)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := r.repository.GetRoomSlotTemplate().FindAllByRoomID(ctx, room.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	roomSchedules, err := r.repository.GetRoomSchedule().FindAllByRoomIDAndDateRange(ctx, room.ID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	closures, err := r.repository.GetClosure().FindAllByRoomAndDateRange(ctx, room, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make(map[string]models.RoomSchedule, len(roomSchedules))
+	for _, item := range roomSchedules {
+		schedules[fmt.Sprintf("%s-%d", item.Date.Format(time.DateOnly), item.TimeID)] = item
+	}
+
+	days := make([]dto.CalendarDayResponse, 0)
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		calendarDay := dto.CalendarDayResponse{
+			Date:    day.Format(time.DateOnly),
+			Weekday: day.Weekday().String(),
+			Slots:   make([]dto.CalendarSlotResponse, 0, len(timeSlots)),
+		}
+
+		for _, closure := range closures {
+			if closure.Covers(room.ID, day) {
+				calendarDay.ClosedReason = closure.Reason
+				break
+			}
+		}
+
+		for _, item := range timeSlots {
+			schedule, ok := schedules[fmt.Sprintf("%s-%d", day.Format(time.DateOnly), item.ID)]
+			if !ok && !templates.Allows(day.Weekday(), item.ID) {
+				continue
+			}
+
+			slot := dto.CalendarSlotResponse{
+				TimeID:    item.UUID,
+				StartTime: util.FormatClock(item.StartTime),
+				EndTime:   util.FormatClock(item.EndTime),
+				Status:    constants.EmptyString,
+			}
+			if ok {
+				slot.ScheduleID = &schedule.UUID
+				slot.Status = schedule.Status.GetStatusString()
+			}
+
+			calendarDay.Slots = append(calendarDay.Slots, slot)
+		}
+
+		days = append(days, calendarDay)
+	}
+
+	return &dto.CalendarResponse{
+		RoomID:    room.UUID,
+		RoomName:  room.Name,
+		View:      view,
+		StartDate: startDate.Format(time.DateOnly),
+		EndDate:   endDate.Format(time.DateOnly),
+		Days:      days,
+	}, nil
+}
+
 func (r *RoomScheduleService) GetByUUID(ctx context.Context, uuid string) (*dto.RoomScheduleResponse, error) {
-	roomSchedule, err := r.repository.GetRoomSchedule().FindByUUID(ctx, uuid)
+	roomSchedule, err := r.findByUUID(ctx, uuid)
 	if err != nil {
 		return nil, err
 	}
@@ -145,19 +357,41 @@ func (r *RoomScheduleService) GetByUUID(ctx context.Context, uuid string) (*dto.
 }
 
 func (r *RoomScheduleService) Create(ctx context.Context, request *dto.RoomScheduleRequest) error {
-	room, err := r.repository.GetRoom().FindByUUID(ctx, request.RoomID)
+	room, err := r.findRoom(ctx, request.RoomID)
 	if err != nil {
 		return err
 	}
 
 	roomSchedules := make([]models.RoomSchedule, 0, len(request.TimeIDs))
 	dateParsed, _ := time.Parse(time.DateOnly, request.Date)
+	closures, err := r.repository.GetClosure().FindAllByRoomAndDateRange(ctx, room, dateParsed, dateParsed)
+	if err != nil {
+		return err
+	}
+
+	if len(closures) > 0 {
+		return errClosure.ErrRoomClosed
+	}
+
+	templates, err := r.repository.GetRoomSlotTemplate().FindAllByRoomID(ctx, room.ID)
+	if err != nil {
+		return err
+	}
+
 	for _, timeID := range request.TimeIDs {
 		scheduleTime, err := r.repository.GetTime().FindByUUID(ctx, timeID)
 		if err != nil {
 			return err
 		}
 
+		if scheduleTime.Library != room.Library {
+			return errTime.ErrTimeNotFound
+		}
+
+		if !templates.Allows(dateParsed.Weekday(), scheduleTime.ID) {
+			return errRoomSchedule.ErrRoomScheduleOutsideHours
+		}
+
 		schedule, err := r.repository.GetRoomSchedule().FindByDateAndTimeID(ctx, request.Date, int(scheduleTime.ID), int(room.ID))
 		if err != nil {
 			return err
@@ -185,53 +419,224 @@ func (r *RoomScheduleService) Create(ctx context.Context, request *dto.RoomSched
 
 }
 
+// maxGenerateDays bounds a single generation request to about a year.
+const maxGenerateDays = 366
+
 func (r *RoomScheduleService) GenerateScheduleForOneMonth(ctx context.Context, request *dto.GenerateRoomScheduleForOneMostRequest) error {
-	room, err := r.repository.GetRoom().FindByUUID(ctx, request.RoomID)
+	room, err := r.findRoom(ctx, request.RoomID)
 	if err != nil {
 		return err
 	}
 
-	timeSlots, err := r.repository.GetTime().FindAll(ctx)
+	timeSlots, err := r.repository.GetTime().FindAll(ctx, room.Library)
 	if err != nil {
 		return err
 	}
 
-	numberOfDays := 30
-	roomSchedules := make([]models.RoomSchedule, 0, numberOfDays)
-	now := time.Now().Add(24 * time.Hour)
+	startDate := util.TruncateToDate(time.Now()).AddDate(0, 0, 1)
+	endDate := startDate.AddDate(0, 0, 29)
+	_, err = r.generate(ctx, room, startDate, endDate, nil, timeSlots, false)
+	return err
+}
+
+func (r *RoomScheduleService) GenerateSchedule(ctx context.Context, request *dto.GenerateRoomScheduleRequest) (*dto.GenerateRoomScheduleResponse, error) {
+	room, err := r.findRoom(ctx, request.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, err := time.Parse(time.DateOnly, request.StartDate)
+	if err != nil {
+		return nil, errRoomSchedule.ErrInvalidDateRange
+	}
 
-	for i := 0; i < numberOfDays; i++ {
-		currentDate := now.AddDate(0, 0, i)
-		for _, item := range timeSlots {
-			schedule, err := r.repository.GetRoomSchedule().FindByDateAndTimeID(ctx, currentDate.Format(time.DateOnly), int(item.ID), int(room.ID))
+	endDate, err := time.Parse(time.DateOnly, request.EndDate)
+	if err != nil {
+		return nil, errRoomSchedule.ErrInvalidDateRange
+	}
+
+	if endDate.Before(startDate) || endDate.Sub(startDate) > maxGenerateDays*24*time.Hour {
+		return nil, errRoomSchedule.ErrInvalidDateRange
+	}
+
+	timeSlots, err := r.findTimeSlots(ctx, room, request.TimeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.generate(ctx, room, startDate, endDate, request.Weekdays, timeSlots, request.Mode != constants.GenerateModeFail)
+}
+
+// GenerateRollingWindow fills the next daysAhead days, starting tomorrow, of
+// every room with the time slots of its library. Existing slots and closed
+// days are skipped, so it is safe to run repeatedly. It returns the number of
+// schedules created.
+func (r *RoomScheduleService) GenerateRollingWindow(ctx context.Context, daysAhead int) (int, error) {
+	rooms, err := r.repository.GetRoom().FindAllWithoutPagination(ctx, "", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	startDate := util.TruncateToDate(time.Now()).AddDate(0, 0, 1)
+	endDate := startDate.AddDate(0, 0, daysAhead-1)
+	timeSlotsByLibrary := make(map[string][]models.Time)
+	created := 0
+	for _, room := range rooms {
+		timeSlots, ok := timeSlotsByLibrary[room.Library]
+		if !ok {
+			timeSlots, err = r.repository.GetTime().FindAll(ctx, room.Library)
 			if err != nil {
-				return err
+				return created, err
 			}
+			timeSlotsByLibrary[room.Library] = timeSlots
+		}
+
+		result, err := r.generate(ctx, &room, startDate, endDate, nil, timeSlots, true)
+		if err != nil {
+			return created, err
+		}
+		created += len(result.Created)
+	}
+
+	return created, nil
+}
 
-			if schedule != nil {
-				return errRoomSchedule.ErrRoomScheduleIsExist
+// findTimeSlots resolves the requested time slots of the room's library,
+// defaulting to all of them.
+func (r *RoomScheduleService) findTimeSlots(ctx context.Context, room *models.Room, timeIDs []string) ([]models.Time, error) {
+	if len(timeIDs) == 0 {
+		return r.repository.GetTime().FindAll(ctx, room.Library)
+	}
+
+	timeSlots := make([]models.Time, 0, len(timeIDs))
+	for _, timeID := range timeIDs {
+		scheduleTime, err := r.repository.GetTime().FindByUUID(ctx, timeID)
+		if err != nil {
+			return nil, err
+		}
+
+		if scheduleTime.Library != room.Library {
+			return nil, errTime.ErrTimeNotFound
+		}
+
+		timeSlots = append(timeSlots, *scheduleTime)
+	}
+
+	return timeSlots, nil
+}
+
+// generate creates an Available schedule for every time slot on every
+// matching day between startDate and endDate, both inclusive. An empty
+// weekdays list matches every day. Days covered by a closure and slots
+// outside the room's opening hours are always skipped. Slots that already
+// exist are reported as skipped, or fail the whole batch when skipExisting
+// is false.
+func (r *RoomScheduleService) generate(
+	ctx context.Context,
+	room *models.Room,
+	startDate, endDate time.Time,
+	weekdays []int,
+	timeSlots []models.Time,
+	skipExisting bool,
+) (*dto.GenerateRoomScheduleResponse, error) {
+	existingSchedules, err := r.repository.GetRoomSchedule().FindAllByRoomIDAndDateRange(ctx, room.ID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	isExist := make(map[string]bool, len(existingSchedules))
+	for _, item := range existingSchedules {
+		isExist[fmt.Sprintf("%s-%d", item.Date.Format(time.DateOnly), item.TimeID)] = true
+	}
+
+	closures, err := r.repository.GetClosure().FindAllByRoomAndDateRange(ctx, room, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := r.repository.GetRoomSlotTemplate().FindAllByRoomID(ctx, room.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	isWeekday := make(map[time.Weekday]bool, len(weekdays))
+	for _, weekday := range weekdays {
+		isWeekday[time.Weekday(weekday)] = true
+	}
+
+	result := &dto.GenerateRoomScheduleResponse{
+		Created: make([]dto.GeneratedRoomScheduleResponse, 0),
+		Skipped: make([]dto.GeneratedRoomScheduleResponse, 0),
+	}
+	roomSchedules := make([]models.RoomSchedule, 0)
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		if len(isWeekday) > 0 && !isWeekday[date.Weekday()] {
+			continue
+		}
+
+		closedReason := ""
+		for _, closure := range closures {
+			if closure.Covers(room.ID, date) {
+				closedReason = closure.Reason
+				break
+			}
+		}
+
+		for _, item := range timeSlots {
+			generated := dto.GeneratedRoomScheduleResponse{
+				Date:      date.Format(time.DateOnly),
+				StartTime: util.FormatClock(item.StartTime),
+				EndTime:   util.FormatClock(item.EndTime),
+			}
+
+			if closedReason != "" {
+				generated.Reason = closedReason
+				result.Skipped = append(result.Skipped, generated)
+				continue
+			}
+
+			if !templates.Allows(date.Weekday(), item.ID) {
+				generated.Reason = errRoomSchedule.ErrRoomScheduleOutsideHours.Error()
+				result.Skipped = append(result.Skipped, generated)
+				continue
+			}
+
+			if isExist[fmt.Sprintf("%s-%d", date.Format(time.DateOnly), item.ID)] {
+				if !skipExisting {
+					return nil, errRoomSchedule.ErrRoomScheduleIsExist
+				}
+
+				generated.Reason = errRoomSchedule.ErrRoomScheduleIsExist.Error()
+				result.Skipped = append(result.Skipped, generated)
+				continue
 			}
 
 			roomSchedules = append(roomSchedules, models.RoomSchedule{
 				UUID:   uuid.New(),
 				RoomID: room.ID,
 				TimeID: item.ID,
-				Date:   currentDate,
+				Date:   date,
 				Status: constants.Available,
 			})
+			result.Created = append(result.Created, generated)
 		}
 	}
 
-	err = r.repository.GetRoomSchedule().Create(ctx, roomSchedules)
-	if err != nil {
-		return err
+	if len(roomSchedules) > 0 {
+		err = r.repository.GetRoomSchedule().Create(ctx, roomSchedules)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return nil
+	return result, nil
 }
 
+// Update moves the schedule to another date and time slot. Only Available
+// and Blocked schedules can be moved, since a booking or hold would silently
+// move with the slot, and never onto a date the room is closed.
 func (r *RoomScheduleService) Update(ctx context.Context, uuid string, request *dto.UpdateRoomScheduleRequest) (*dto.RoomScheduleResponse, error) {
-	roomSchedule, err := r.repository.GetRoomSchedule().FindByUUID(ctx, uuid)
+	roomSchedule, err := r.findByUUID(ctx, uuid)
 	if err != nil {
 		return nil, err
 	}
@@ -241,31 +646,71 @@ func (r *RoomScheduleService) Update(ctx context.Context, uuid string, request *
 		return nil, err
 	}
 
-	isTimeExist, err := r.repository.GetRoomSchedule().FindByDateAndTimeID(ctx, request.Date, int(scheduleTime.ID), int(roomSchedule.RoomID))
+	if scheduleTime.Library != roomSchedule.Room.Library {
+		return nil, errTime.ErrTimeNotFound
+	}
+
+	dateParsed, err := time.Parse(time.DateOnly, request.Date)
+	if err != nil {
+		return nil, errRoomSchedule.ErrInvalidDateRange
+	}
+
+	closures, err := r.repository.GetClosure().FindAllByRoomAndDateRange(ctx, &roomSchedule.Room, dateParsed, dateParsed)
 	if err != nil {
 		return nil, err
 	}
 
-	if isTimeExist != nil && request.Date != roomSchedule.Date.Format(time.DateOnly) {
-		checkDate, err := r.repository.GetRoomSchedule().FindByDateAndTimeID(ctx, request.Date, int(scheduleTime.ID), int(roomSchedule.RoomID))
+	if len(closures) > 0 {
+		return nil, errClosure.ErrRoomClosed
+	}
+
+	templates, err := r.repository.GetRoomSlotTemplate().FindAllByRoomID(ctx, roomSchedule.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !templates.Allows(dateParsed.Weekday(), scheduleTime.ID) {
+		return nil, errRoomSchedule.ErrRoomScheduleOutsideHours
+	}
+
+	existing, err := r.repository.GetRoomSchedule().FindByDateAndTimeID(ctx, request.Date, int(scheduleTime.ID), int(roomSchedule.RoomID))
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil && existing.ID != roomSchedule.ID {
+		return nil, errRoomSchedule.ErrRoomScheduleIsExist
+	}
+
+	err = r.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		roomSchedules, err := r.repository.GetRoomSchedule().FindAllByUUIDsForUpdate(ctx, tx, []string{uuid})
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		if checkDate != nil {
-			return nil, errRoomSchedule.ErrRoomScheduleIsExist
+		if len(roomSchedules) == 0 {
+			return errRoomSchedule.ErrRoomScheduleNotFound
 		}
-	}
 
-	dateParsed, _ := time.Parse(time.DateOnly, request.Date)
-	roomResult, err := r.repository.GetRoomSchedule().Update(ctx, uuid, &models.RoomSchedule{
-		Date:   dateParsed,
-		TimeID: scheduleTime.ID,
+		status := roomSchedules[0].Status
+		if status != constants.Available && status != constants.Blocked {
+			return errRoomSchedule.ErrRoomScheduleNotMovable
+		}
+
+		return r.repository.GetRoomSchedule().Update(ctx, tx, uuid, &models.RoomSchedule{
+			Date:   dateParsed,
+			TimeID: scheduleTime.ID,
+		})
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	roomResult, err := r.repository.GetRoomSchedule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
 	response := dto.RoomScheduleResponse{
 		UUID:        roomResult.UUID,
 		RoomName:    roomResult.Room.Name,
@@ -273,30 +718,61 @@ func (r *RoomScheduleService) Update(ctx context.Context, uuid string, request *
 		Capacity:    roomResult.Room.Capacity,
 		Description: roomResult.Room.Description,
 		Status:      roomResult.Status.GetStatusString(),
-		Time:        fmt.Sprintf("%s - %s", scheduleTime.StartTime, scheduleTime.EndTime),
+		Time:        fmt.Sprintf("%s - %s", util.FormatClock(roomResult.Time.StartTime), util.FormatClock(roomResult.Time.EndTime)),
 		CreatedAt:   *roomResult.CreatedAt,
 		UpdatedAt:   *roomResult.UpdatedAt,
 	}
 	return &response, nil
 }
 
-func (r *RoomScheduleService) UpdateStatus(ctx context.Context, request *dto.UpdateStatusRoomScheduleRequest) error {
-	for _, item := range request.RoomScheduleIDs {
-		_, err := r.repository.GetRoomSchedule().FindByUUID(ctx, item)
+// UpdateStatus lets staff put an available slot under maintenance and lift
+// the block again. Every other status is driven by bookings.
+func (r *RoomScheduleService) UpdateStatus(ctx context.Context, uuid string, request *dto.UpdateStatusRoomScheduleRequest) (*dto.RoomScheduleResponse, error) {
+	status := request.Status.GetStatusInt()
+	if status != constants.Blocked && status != constants.Available {
+		return nil, errRoomSchedule.ErrRoomScheduleInvalidStatus
+	}
+
+	_, err := r.findByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		roomSchedules, err := r.repository.GetRoomSchedule().FindAllByUUIDsForUpdate(ctx, tx, []string{uuid})
 		if err != nil {
 			return err
 		}
 
-		err = r.repository.GetRoomSchedule().UpdateStatus(ctx, constants.Booked, item)
+		if len(roomSchedules) == 0 {
+			return errRoomSchedule.ErrRoomScheduleNotFound
+		}
+
+		roomSchedule := roomSchedules[0]
+		if (status == constants.Blocked && roomSchedule.Status != constants.Available) ||
+			(status == constants.Available && roomSchedule.Status != constants.Blocked) {
+			return &errRoomSchedule.InvalidStatusTransitionError{
+				From: roomSchedule.Status.GetStatusString(),
+				To:   request.Status,
+			}
+		}
+
+		err = roomSchedule.TransitionTo(status)
 		if err != nil {
 			return err
 		}
+
+		return r.repository.GetRoomSchedule().UpdateStatus(ctx, tx, roomSchedule.Status, uuid)
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	return r.GetByUUID(ctx, uuid)
 }
 
 func (r *RoomScheduleService) Delete(ctx context.Context, uuid string) error {
-	_, err := r.repository.GetRoomSchedule().FindByUUID(ctx, uuid)
+	_, err := r.findByUUID(ctx, uuid)
 	if err != nil {
 		return err
 	}
@@ -307,3 +783,177 @@ func (r *RoomScheduleService) Delete(ctx context.Context, uuid string) error {
 	}
 	return nil
 }
+
+// lockHeldSchedules locks the requested schedules, making sure every one of
+// them exists and belongs to the user's library.
+func (r *RoomScheduleService) lockHeldSchedules(ctx context.Context, tx *gorm.DB, uuids []string) ([]models.RoomSchedule, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	uniqueIDs := make(map[string]bool, len(uuids))
+	for _, item := range uuids {
+		uniqueIDs[item] = true
+	}
+
+	roomSchedules, err := r.repository.GetRoomSchedule().FindAllByUUIDsForUpdate(ctx, tx, uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roomSchedules) != len(uniqueIDs) {
+		return nil, errRoomSchedule.ErrRoomScheduleNotFound
+	}
+
+	for _, roomSchedule := range roomSchedules {
+		if user.Library != "" && roomSchedule.Room.Library != user.Library {
+			return nil, errRoomSchedule.ErrRoomScheduleNotFound
+		}
+	}
+
+	return roomSchedules, nil
+}
+
+// Hold reserves available slots for the user while they confirm the
+// booking. Slots the user already holds keep their current hold, and
+// suspended users cannot hold any.
+func (r *RoomScheduleService) Hold(ctx context.Context, request *dto.HoldRoomScheduleRequest) ([]dto.HeldRoomScheduleResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	now := time.Now()
+	heldUntil := now.Add(time.Duration(config.Config.Hold.DurationMinute) * time.Minute)
+	var results []dto.HeldRoomScheduleResponse
+	err := r.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		err := penaltyService.NewPenaltyService(r.repository).CheckSuspension(ctx, tx, user.UUID)
+		if err != nil {
+			return err
+		}
+
+		roomSchedules, err := r.lockHeldSchedules(ctx, tx, request.RoomScheduleIDs)
+		if err != nil {
+			return err
+		}
+
+		held, err := r.repository.GetRoomSchedule().CountHeldByUserID(ctx, tx, user.UUID, now)
+		if err != nil {
+			return err
+		}
+
+		results = make([]dto.HeldRoomScheduleResponse, 0, len(roomSchedules))
+		for _, roomSchedule := range roomSchedules {
+			if !roomSchedule.IsHeldFor(user.UUID, now) {
+				startsAt, err := util.CombineDateAndClock(roomSchedule.Date, roomSchedule.Time.StartTime)
+				if err != nil {
+					return err
+				}
+
+				if !startsAt.After(now) {
+					return errRoomSchedule.ErrRoomScheduleStarted
+				}
+
+				if roomSchedule.Status.IsBooked() {
+					return errRoomSchedule.ErrRoomScheduleAlreadyBooked
+				}
+
+				if roomSchedule.Status != constants.Available {
+					return errRoomSchedule.ErrRoomScheduleNotAvailable
+				}
+
+				held++
+				if config.Config.Hold.MaxSlots > 0 && held > int64(config.Config.Hold.MaxSlots) {
+					return errRoomSchedule.ErrRoomScheduleHoldLimit
+				}
+
+				err = roomSchedule.TransitionTo(constants.Held)
+				if err != nil {
+					return err
+				}
+
+				err = r.repository.GetRoomSchedule().Hold(ctx, tx, roomSchedule.UUID.String(), user.UUID, heldUntil)
+				if err != nil {
+					return err
+				}
+				roomSchedule.HeldUntil = &heldUntil
+			}
+
+			results = append(results, dto.HeldRoomScheduleResponse{
+				UUID:      roomSchedule.UUID,
+				RoomID:    roomSchedule.Room.UUID,
+				RoomName:  roomSchedule.Room.Name,
+				Date:      roomSchedule.Date.Format(time.DateOnly),
+				StartTime: util.FormatClock(roomSchedule.Time.StartTime),
+				EndTime:   util.FormatClock(roomSchedule.Time.EndTime),
+				HeldUntil: roomSchedule.HeldUntil,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Release gives back the slots the user holds, offering each to its
+// waitlist. Slots the user does not hold are left alone.
+func (r *RoomScheduleService) Release(ctx context.Context, request *dto.HoldRoomScheduleRequest) error {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	return r.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		roomSchedules, err := r.lockHeldSchedules(ctx, tx, request.RoomScheduleIDs)
+		if err != nil {
+			return err
+		}
+
+		for _, roomSchedule := range roomSchedules {
+			if !roomSchedule.IsHeldFor(user.UUID, time.Now()) {
+				continue
+			}
+
+			err = r.release(ctx, tx, &roomSchedule)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ReleaseExpiredHolds releases every hold that has run out, returning how
+// many slots were released.
+func (r *RoomScheduleService) ReleaseExpiredHolds(ctx context.Context) (int, error) {
+	released := 0
+	err := r.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		roomSchedules, err := r.repository.GetRoomSchedule().FindAllExpiredHoldsForUpdate(ctx, tx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		for _, roomSchedule := range roomSchedules {
+			err = r.release(ctx, tx, &roomSchedule)
+			if err != nil {
+				return err
+			}
+			released++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return released, nil
+}
+
+func (r *RoomScheduleService) release(ctx context.Context, tx *gorm.DB, roomSchedule *models.RoomSchedule) error {
+	err := roomSchedule.TransitionTo(constants.Available)
+	if err != nil {
+		return err
+	}
+
+	err = r.repository.GetRoomSchedule().UpdateStatus(ctx, tx, roomSchedule.Status, roomSchedule.UUID.String())
+	if err != nil {
+		return err
+	}
+
+	return waitlistService.NewWaitlistService(r.repository).OfferNext(ctx, tx, roomSchedule)
+}
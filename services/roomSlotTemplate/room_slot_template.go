@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"room-service/common/util"
+	"room-service/constants"
+	errRoom "room-service/constants/error/room"
+	errTime "room-service/constants/error/time"
+	"room-service/domain/dto"
+	"room-service/domain/models"
+	"room-service/repositories"
+
+	"gorm.io/gorm"
+)
+
+type RoomSlotTemplateService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IRoomSlotTemplateService interface {
+	GetByRoomID(context.Context, string) ([]dto.RoomSlotTemplateResponse, error)
+	Replace(context.Context, string, *dto.RoomSlotTemplateRequest) ([]dto.RoomSlotTemplateResponse, error)
+}
+
+func NewRoomSlotTemplateService(repository repositories.IRepositoryRegistry) IRoomSlotTemplateService {
+	return &RoomSlotTemplateService{repository: repository}
+}
+
+func (r *RoomSlotTemplateService) findRoom(ctx context.Context, uuid string) (*models.Room, error) {
+	room, err := r.repository.GetRoom().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	library, _ := ctx.Value(constants.Library).(string)
+	if library != "" && room.Library != library {
+		return nil, errRoom.ErrRoomNotFound
+	}
+
+	return room, nil
+}
+
+func (r *RoomSlotTemplateService) GetByRoomID(ctx context.Context, roomID string) ([]dto.RoomSlotTemplateResponse, error) {
+	room, err := r.findRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := r.repository.GetRoomSlotTemplate().FindAllByRoomID(ctx, room.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	templateResults := make([]dto.RoomSlotTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		templateResults = append(templateResults, dto.RoomSlotTemplateResponse{
+			UUID:      template.UUID,
+			Weekday:   template.Weekday,
+			TimeID:    template.Time.UUID,
+			StartTime: util.FormatClock(template.Time.StartTime),
+			EndTime:   util.FormatClock(template.Time.EndTime),
+		})
+	}
+
+	return templateResults, nil
+}
+
+// Replace sets the room's opening hours to the given slots. An empty list
+// removes the template, opening the room for every time slot of its library.
+func (r *RoomSlotTemplateService) Replace(ctx context.Context, roomID string, request *dto.RoomSlotTemplateRequest) ([]dto.RoomSlotTemplateResponse, error) {
+	room, err := r.findRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make(models.RoomSlotTemplates, 0, len(request.Templates))
+	for _, item := range request.Templates {
+		scheduleTime, err := r.repository.GetTime().FindByUUID(ctx, item.TimeID)
+		if err != nil {
+			return nil, err
+		}
+
+		if scheduleTime.Library != room.Library {
+			return nil, errTime.ErrTimeNotFound
+		}
+
+		templates = append(templates, models.RoomSlotTemplate{
+			Weekday: item.Weekday,
+			TimeID:  scheduleTime.ID,
+		})
+	}
+
+	err = r.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		return r.repository.GetRoomSlotTemplate().Replace(ctx, tx, room.ID, templates)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByRoomID(ctx, roomID)
+}
@@ -2,9 +2,14 @@ package services
 
 import (
 	"context"
+	"room-service/common/util"
+	"room-service/constants"
+	errTime "room-service/constants/error/time"
 	"room-service/domain/dto"
 	"room-service/domain/models"
 	"room-service/repositories"
+
+	"gorm.io/gorm"
 )
 
 type TimeService struct {
@@ -15,6 +20,8 @@ type ITimeService interface {
 	GetAll(context.Context) ([]dto.TimeResponse, error)
 	GetByUUID(context.Context, string) (*dto.TimeResponse, error)
 	Create(context.Context, *dto.TimeRequest) (*dto.TimeResponse, error)
+	Update(context.Context, string, *dto.TimeRequest) (*dto.TimeResponse, error)
+	Delete(context.Context, string, bool) error
 }
 
 func NewTimeService(repository repositories.IRepositoryRegistry) ITimeService {
@@ -23,63 +30,181 @@ func NewTimeService(repository repositories.IRepositoryRegistry) ITimeService {
 	}
 }
 
+func (t *TimeService) toTimeResponse(time *models.Time) dto.TimeResponse {
+	return dto.TimeResponse{
+		UUID:      time.UUID,
+		StartTime: util.FormatClock(time.StartTime),
+		EndTime:   util.FormatClock(time.EndTime),
+		CreatedAt: time.CreatedAt,
+		UpdatedAt: time.UpdatedAt,
+	}
+}
+
 func (t *TimeService) GetAll(ctx context.Context) ([]dto.TimeResponse, error) {
-	times, err := t.repository.GetTime().FindAll(ctx)
+	library, _ := ctx.Value(constants.Library).(string)
+	times, err := t.repository.GetTime().FindAll(ctx, library)
 	if err != nil {
 		return nil, err
 	}
 
 	timeResults := make([]dto.TimeResponse, 0, len(times))
 	for _, time := range times {
-		timeResults = append(timeResults, dto.TimeResponse{
-			UUID:      time.UUID,
-			StartTime: time.StartTime,
-			EndTime:   time.EndTime,
-			CreatedAt: time.CreatedAt,
-			UpdatedAt: time.UpdatedAt,
-		})
+		timeResults = append(timeResults, t.toTimeResponse(&time))
 	}
 
 	return timeResults, nil
 }
 
-func (t *TimeService) GetByUUID(ctx context.Context, uuid string) (*dto.TimeResponse, error) {
+func (t *TimeService) findByUUID(ctx context.Context, uuid string) (*models.Time, error) {
 	time, err := t.repository.GetTime().FindByUUID(ctx, uuid)
 	if err != nil {
 		return nil, err
 	}
 
-	timeResults := dto.TimeResponse{
-		UUID:      time.UUID,
-		StartTime: time.StartTime,
-		EndTime:   time.EndTime,
-		CreatedAt: time.CreatedAt,
-		UpdatedAt: time.UpdatedAt,
+	library, _ := ctx.Value(constants.Library).(string)
+	if library != "" && time.Library != library {
+		return nil, errTime.ErrTimeNotFound
+	}
+
+	return time, nil
+}
+
+func (t *TimeService) GetByUUID(ctx context.Context, uuid string) (*dto.TimeResponse, error) {
+	time, err := t.findByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
 	}
+
+	timeResults := t.toTimeResponse(time)
 	return &timeResults, nil
 }
 
+// validate normalizes the requested range to HH:MM and checks that it is
+// well ordered and does not overlap another slot of the library, other than
+// the slot with excludeID.
+func (t *TimeService) validate(ctx context.Context, library string, req *dto.TimeRequest, excludeID uint) (string, string, error) {
+	startTime, err := util.ParseClock(req.StartTime)
+	if err != nil {
+		return "", "", errTime.ErrInvalidTimeRange
+	}
+
+	endTime, err := util.ParseClock(req.EndTime)
+	if err != nil {
+		return "", "", errTime.ErrInvalidTimeRange
+	}
+
+	if !startTime.Before(endTime) {
+		return "", "", errTime.ErrInvalidTimeRange
+	}
+
+	start := startTime.Format("15:04")
+	end := endTime.Format("15:04")
+	overlapping, err := t.repository.GetTime().FindOverlapping(ctx, library, start, end, excludeID)
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(overlapping) > 0 {
+		return "", "", errTime.ErrTimeOverlap
+	}
+
+	return start, end, nil
+}
+
 func (t *TimeService) Create(ctx context.Context, req *dto.TimeRequest) (*dto.TimeResponse, error) {
-	time := &dto.TimeRequest{
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
+	library, _ := ctx.Value(constants.Library).(string)
+	startTime, endTime, err := t.validate(ctx, library, req, 0)
+	if err != nil {
+		return nil, err
 	}
 
 	timeResult, err := t.repository.GetTime().Create(ctx, &models.Time{
-		StartTime: time.StartTime,
-		EndTime:   time.EndTime,
+		Library:   library,
+		StartTime: startTime,
+		EndTime:   endTime,
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	response := dto.TimeResponse{
-		UUID:      timeResult.UUID,
-		StartTime: timeResult.StartTime,
-		EndTime:   timeResult.EndTime,
-		CreatedAt: timeResult.CreatedAt,
-		UpdatedAt: timeResult.UpdatedAt,
+	response := t.toTimeResponse(timeResult)
+	return &response, nil
+}
+
+// Update changes the slot's start and end. Slots whose schedules hold active
+// bookings cannot be changed, as that would move the bookings with them.
+func (t *TimeService) Update(ctx context.Context, uuid string, req *dto.TimeRequest) (*dto.TimeResponse, error) {
+	time, err := t.findByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime, endTime, err := t.validate(ctx, time.Library, req, time.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	booked, err := t.repository.GetRoomSchedule().CountByTimeID(ctx, time.ID, constants.BookedStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	if booked > 0 {
+		return nil, errTime.ErrTimeHasActiveBookings
 	}
+
+	timeResult, err := t.repository.GetTime().Update(ctx, uuid, &models.Time{
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := t.toTimeResponse(timeResult)
 	return &response, nil
 }
+
+// Delete removes the time slot. Slots still used by room schedules are only
+// removed, together with those schedules, when cascade is set, and never
+// while any of the schedules holds an active booking. The slot and its
+// schedules are locked while this is checked, so no schedule can be created
+// or booked on it before it is gone.
+func (t *TimeService) Delete(ctx context.Context, uuid string, cascade bool) error {
+	_, err := t.findByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return t.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		time, err := t.repository.GetTime().FindByUUIDForUpdate(ctx, tx, uuid)
+		if err != nil {
+			return err
+		}
+
+		roomSchedules, err := t.repository.GetRoomSchedule().FindAllByTimeIDForUpdate(ctx, tx, time.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(roomSchedules) > 0 {
+			if !cascade {
+				return errTime.ErrTimeInUse
+			}
+
+			for _, roomSchedule := range roomSchedules {
+				if roomSchedule.Status.IsBooked() {
+					return errTime.ErrTimeHasActiveBookings
+				}
+			}
+
+			err = t.repository.GetRoomSchedule().DeleteByTimeID(ctx, tx, time.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return t.repository.GetTime().Delete(ctx, tx, uuid)
+	})
+}
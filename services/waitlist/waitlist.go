@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	clients "room-service/clients/user"
+	"room-service/common/util"
+	"room-service/config"
+	"room-service/constants"
+	errBooking "room-service/constants/error/booking"
+	errRoomSchedule "room-service/constants/error/roomSchedule"
+	errWaitlist "room-service/constants/error/waitlist"
+	"room-service/domain/dto"
+	"room-service/domain/models"
+	"room-service/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WaitlistService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IWaitlistService interface {
+	GetAllByUser(context.Context) ([]dto.WaitlistResponse, error)
+	GetAllByRoomSchedule(context.Context, string) ([]dto.WaitlistResponse, error)
+	Join(context.Context, *dto.WaitlistRequest) (*dto.WaitlistResponse, error)
+	Cancel(context.Context, string) (*dto.WaitlistResponse, error)
+	ExpireOffers(context.Context) (int, error)
+	OfferNext(context.Context, *gorm.DB, *models.RoomSchedule) error
+}
+
+func NewWaitlistService(repository repositories.IRepositoryRegistry) IWaitlistService {
+	return &WaitlistService{repository: repository}
+}
+
+func (w *WaitlistService) toWaitlistResponse(ctx context.Context, entry *models.WaitlistEntry) (*dto.WaitlistResponse, error) {
+	response := &dto.WaitlistResponse{
+		UUID:           entry.UUID,
+		RoomScheduleID: entry.RoomSchedule.UUID,
+		RoomID:         entry.RoomSchedule.Room.UUID,
+		RoomName:       entry.RoomSchedule.Room.Name,
+		Date:           entry.RoomSchedule.Date.Format(time.DateOnly),
+		StartTime:      util.FormatClock(entry.RoomSchedule.Time.StartTime),
+		EndTime:        util.FormatClock(entry.RoomSchedule.Time.EndTime),
+		UserID:         entry.UserID,
+		UserName:       entry.UserName,
+		Purpose:        entry.Purpose,
+		Attendees:      entry.Attendees,
+		Status:         entry.Status.GetStatusString(),
+		OfferedAt:      entry.OfferedAt,
+		OfferExpiresAt: entry.OfferExpiresAt,
+		CreatedAt:      entry.CreatedAt,
+	}
+
+	if entry.Status == constants.WaitlistWaiting {
+		ahead, err := w.repository.GetWaitlist().CountWaitingAhead(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+		response.Position = int(ahead) + 1
+	}
+
+	return response, nil
+}
+
+func (w *WaitlistService) toWaitlistResponses(ctx context.Context, entries []models.WaitlistEntry) ([]dto.WaitlistResponse, error) {
+	entryResults := make([]dto.WaitlistResponse, 0, len(entries))
+	for _, entry := range entries {
+		response, err := w.toWaitlistResponse(ctx, &entry)
+		if err != nil {
+			return nil, err
+		}
+		entryResults = append(entryResults, *response)
+	}
+
+	return entryResults, nil
+}
+
+func (w *WaitlistService) findRoomSchedule(ctx context.Context, uuid string) (*models.RoomSchedule, error) {
+	roomSchedule, err := w.repository.GetRoomSchedule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	library, _ := ctx.Value(constants.Library).(string)
+	if library != "" && roomSchedule.Room.Library != library {
+		return nil, errRoomSchedule.ErrRoomScheduleNotFound
+	}
+
+	return roomSchedule, nil
+}
+
+func (w *WaitlistService) GetAllByUser(ctx context.Context) ([]dto.WaitlistResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	entries, err := w.repository.GetWaitlist().FindAllByUserID(ctx, user.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.toWaitlistResponses(ctx, entries)
+}
+
+func (w *WaitlistService) GetAllByRoomSchedule(ctx context.Context, uuid string) ([]dto.WaitlistResponse, error) {
+	roomSchedule, err := w.findRoomSchedule(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := w.repository.GetWaitlist().FindAllActiveByRoomScheduleID(ctx, roomSchedule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.toWaitlistResponses(ctx, entries)
+}
+
+// Join queues the user for a slot that is taken. Slots that are still
+// available must be booked directly.
+func (w *WaitlistService) Join(ctx context.Context, request *dto.WaitlistRequest) (*dto.WaitlistResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	roomSchedule, err := w.findRoomSchedule(ctx, request.RoomScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	startsAt, err := util.CombineDateAndClock(roomSchedule.Date, roomSchedule.Time.StartTime)
+	if err != nil {
+		return nil, err
+	}
+
+	if !startsAt.After(time.Now()) {
+		return nil, errWaitlist.ErrWaitlistSchedulePassed
+	}
+
+	if roomSchedule.Status == constants.Available {
+		return nil, errWaitlist.ErrWaitlistScheduleOpen
+	}
+
+	if !roomSchedule.Status.IsBooked() && roomSchedule.Status != constants.Held {
+		return nil, errRoomSchedule.ErrRoomScheduleNotAvailable
+	}
+
+	if roomSchedule.IsHeldFor(user.UUID, time.Now()) {
+		return nil, errWaitlist.ErrWaitlistOwnBooking
+	}
+
+	if roomSchedule.Status.IsBooked() {
+		booking, err := w.repository.GetBooking().FindActiveByRoomScheduleID(ctx, w.repository.GetTx(), roomSchedule.ID)
+		if err == nil && booking.UserID == user.UUID {
+			return nil, errWaitlist.ErrWaitlistOwnBooking
+		}
+	}
+
+	if request.Attendees > roomSchedule.Room.Capacity {
+		return nil, errBooking.ErrBookingExceedsCapacity
+	}
+
+	entry, err := w.repository.GetWaitlist().Create(ctx, &models.WaitlistEntry{
+		RoomScheduleID: roomSchedule.ID,
+		UserID:         user.UUID,
+		UserName:       user.Name,
+		UserRole:       user.Role,
+		Purpose:        request.Purpose,
+		Attendees:      request.Attendees,
+		Status:         constants.WaitlistWaiting,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entry.RoomSchedule = *roomSchedule
+	return w.toWaitlistResponse(ctx, entry)
+}
+
+// Cancel takes the user off the queue. Declining an open offer passes the
+// slot on to the next user in line.
+func (w *WaitlistService) Cancel(ctx context.Context, uuid string) (*dto.WaitlistResponse, error) {
+	user := ctx.Value(constants.User).(*clients.UserData)
+	err := w.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		entry, err := w.repository.GetWaitlist().FindByUUIDForUpdate(ctx, tx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if entry.UserID != user.UUID {
+			return errWaitlist.ErrWaitlistNotFound
+		}
+
+		if entry.Status != constants.WaitlistWaiting && entry.Status != constants.WaitlistOffered {
+			return errWaitlist.ErrWaitlistNotCancellable
+		}
+
+		err = w.repository.GetWaitlist().UpdateStatus(ctx, tx, uuid, constants.WaitlistCancelled)
+		if err != nil {
+			return err
+		}
+
+		if entry.Status == constants.WaitlistOffered {
+			return w.release(ctx, tx, entry)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := w.repository.GetWaitlist().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.toWaitlistResponse(ctx, entry)
+}
+
+// ExpireOffers closes the offers nobody accepted in time and passes their
+// slots on, returning how many offers expired.
+func (w *WaitlistService) ExpireOffers(ctx context.Context) (int, error) {
+	entries, err := w.repository.GetWaitlist().FindAllExpiredOffers(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, item := range entries {
+		isExpired := false
+		err = w.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+			entry, err := w.repository.GetWaitlist().FindByUUIDForUpdate(ctx, tx, item.UUID.String())
+			if err != nil {
+				return err
+			}
+
+			if entry.Status != constants.WaitlistOffered || entry.OfferExpiresAt.After(time.Now()) {
+				return nil
+			}
+
+			err = w.repository.GetWaitlist().UpdateStatus(ctx, tx, entry.UUID.String(), constants.WaitlistExpired)
+			if err != nil {
+				return err
+			}
+
+			isExpired = true
+			return w.release(ctx, tx, entry)
+		})
+		if err != nil {
+			return expired, err
+		}
+
+		if isExpired {
+			expired++
+		}
+	}
+
+	return expired, nil
+}
+
+// release gives back the slot held for the entry's offer and offers it to
+// the next user in line.
+func (w *WaitlistService) release(ctx context.Context, tx *gorm.DB, entry *models.WaitlistEntry) error {
+	roomSchedule, err := w.repository.GetRoomSchedule().FindByIDForUpdate(ctx, tx, entry.RoomScheduleID)
+	if err != nil {
+		return err
+	}
+
+	if roomSchedule.Status != constants.Held || roomSchedule.HeldBy == nil || *roomSchedule.HeldBy != entry.UserID {
+		return nil
+	}
+
+	err = roomSchedule.TransitionTo(constants.Available)
+	if err != nil {
+		return err
+	}
+
+	err = w.repository.GetRoomSchedule().UpdateStatus(ctx, tx, roomSchedule.Status, roomSchedule.UUID.String())
+	if err != nil {
+		return err
+	}
+
+	return w.OfferNext(ctx, tx, roomSchedule)
+}
+
+// OfferNext holds a slot that has just become available for the first user
+// waiting on it, until the offer runs out or the slot starts. Slots that have
+// already started stay available.
+func (w *WaitlistService) OfferNext(ctx context.Context, tx *gorm.DB, roomSchedule *models.RoomSchedule) error {
+	if roomSchedule.Status != constants.Available {
+		return nil
+	}
+
+	startsAt, err := util.CombineDateAndClock(roomSchedule.Date, roomSchedule.Time.StartTime)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if !startsAt.After(now) {
+		return nil
+	}
+
+	entry, err := w.repository.GetWaitlist().FindNextWaitingForUpdate(ctx, tx, roomSchedule.ID)
+	if err != nil || entry == nil {
+		return err
+	}
+
+	expiresAt := now.Add(time.Duration(config.Config.Waitlist.OfferMinute) * time.Minute)
+	if expiresAt.After(startsAt) {
+		expiresAt = startsAt
+	}
+
+	err = roomSchedule.TransitionTo(constants.Held)
+	if err != nil {
+		return err
+	}
+
+	err = w.repository.GetRoomSchedule().Hold(ctx, tx, roomSchedule.UUID.String(), entry.UserID, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	roomSchedule.HeldBy = &entry.UserID
+	roomSchedule.HeldUntil = &expiresAt
+	return w.repository.GetWaitlist().Offer(ctx, tx, entry.UUID.String(), now, expiresAt)
+}